@@ -0,0 +1,229 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/wellalencarweb/otel-lab-challenge/config"
+	"github.com/wellalencarweb/otel-lab-challenge/internal/entities/dto"
+	"github.com/wellalencarweb/otel-lab-challenge/internal/infra/bulkfile"
+	"github.com/wellalencarweb/otel-lab-challenge/internal/pkg/dependencies"
+	opentelemetry "github.com/wellalencarweb/otel-lab-challenge/internal/pkg/otel"
+)
+
+// errFailedZipCodes tells a complete run with failed zipcodes apart from one that could not
+// finish, so a scheduled job can decide whether to retry the failures or the whole list.
+var errFailedZipCodes = errors.New("some zipcodes failed")
+
+type options struct {
+	input        string
+	inputFormat  string
+	output       string
+	outputFormat string
+	failures     string
+	concurrency  int
+	rps          float64
+	progress     time.Duration
+	trace        bool
+}
+
+// main exits with 1 when the run could not finish, its results being partial, and with 2 when it
+// did but some zipcodes failed.
+func main() {
+	var opts options
+	flag.StringVar(&opts.input, "input", "-", "file with the zipcodes to resolve, - for stdin")
+	flag.StringVar(&opts.inputFormat, "input-format", "", "text, csv or jsonl (default: from the file extension, text for stdin)")
+	flag.StringVar(&opts.output, "output", "-", "file to write the results to, - for stdout")
+	flag.StringVar(&opts.outputFormat, "output-format", "", "jsonl or csv (default: from the file extension, jsonl for stdout)")
+	flag.StringVar(&opts.failures, "failures", "", "file to write the failures to (default: alongside the results)")
+	flag.IntVar(&opts.concurrency, "concurrency", 8, "zipcodes resolved in parallel")
+	flag.Float64Var(&opts.rps, "rps", 0, "zipcodes started per second, 0 for no limit")
+	flag.DurationVar(&opts.progress, "progress", 5*time.Second, "interval between progress reports, 0 to disable")
+	flag.BoolVar(&opts.trace, "trace", false, "export traces to OTEL_COLLECTOR_URL")
+	flag.Parse()
+
+	err := run(opts)
+	if errors.Is(err, errFailedZipCodes) {
+		os.Exit(2)
+	}
+	if err != nil {
+		log.Fatal(err)
+	}
+}
+
+// run returns instead of exiting, so the outputs are always flushed and closed.
+func run(opts options) (err error) {
+	configs, err := config.LoadConfig(".")
+	if err != nil {
+		return err
+	}
+
+	deps := dependencies.ResolveBulkResolveDependencies(configs, dependencies.BulkResolveSettings{
+		Concurrency:      opts.concurrency,
+		RateLimitRPS:     opts.rps,
+		ProgressInterval: opts.progress,
+	})
+	logger := deps.Logger
+
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer cancel()
+
+	if opts.trace {
+		otelProviderShutdownFn, err := opentelemetry.InitProvider(ctx, deps.ServiceName, configs.OtelCollectorURL)
+		if err != nil {
+			return fmt.Errorf("failed to initialize the otel provider: %w", err)
+		}
+
+		defer func() {
+			shutdownCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+			defer cancel()
+
+			if err := otelProviderShutdownFn(shutdownCtx); err != nil {
+				log.Printf("error shutting otel provider down: %s\n", err)
+			}
+		}()
+	}
+
+	reader, err := openInput(opts.input)
+	if err != nil {
+		return fmt.Errorf("error opening input: %w", err)
+	}
+	defer reader.Close()
+
+	results, err := openOutput(opts.output, bulkfile.Format(opts.outputFormat))
+	if err != nil {
+		return fmt.Errorf("error opening output: %w", err)
+	}
+	defer closeOutput(results, &err)
+
+	failed := results
+	if opts.failures != "" {
+		failed, err = openOutput(opts.failures, bulkfile.Format(opts.outputFormat))
+		if err != nil {
+			return fmt.Errorf("error opening failures output: %w", err)
+		}
+		defer closeOutput(failed, &err)
+	}
+
+	format := bulkfile.Format(opts.inputFormat)
+	if format == "" {
+		format = bulkfile.DetectFormat(opts.input, bulkfile.FormatText)
+	}
+
+	zipCodes := make(chan string)
+	readErr := make(chan error, 1)
+
+	go func() {
+		readErr <- bulkfile.ReadZipCodes(ctx, reader, format, zipCodes)
+	}()
+
+	summary := deps.ResolveZipCodesUseCase.Execute(ctx, zipCodes, func(result dto.BulkResolveResult) {
+		writer := results
+		if result.Error != "" {
+			writer = failed
+		}
+
+		if err := writer.Write(result); err != nil {
+			logger.Error().Msgf("[BulkResolve] Error writing result for zipcode [%s]: %s", result.Zipcode, err)
+		}
+	})
+
+	for _, closer := range deps.Closers {
+		if err := closer.Close(); err != nil {
+			logger.Error().Msgf("[BulkResolve] Error closing dependency: %s", err)
+		}
+	}
+
+	logger.Info().Msgf("[BulkResolve] Done: read [%d] zipcodes ([%d] duplicates), resolved [%d], failed [%d] in %s (%.1f/s)",
+		summary.Total, summary.Duplicates, summary.Resolved, summary.Failed, summary.Duration.Round(time.Millisecond), summary.PerSecond())
+
+	if err := ctx.Err(); err != nil {
+		return fmt.Errorf("interrupted, results are partial: %w", err)
+	}
+
+	if err := <-readErr; err != nil {
+		return fmt.Errorf("error reading input, results are partial: %w", err)
+	}
+
+	if summary.Failed > 0 {
+		return errFailedZipCodes
+	}
+
+	return nil
+}
+
+// closeOutput flushes and closes an output, reporting the error through err unless the run
+// already could not finish.
+func closeOutput(o *output, err *error) {
+	if closeErr := o.Close(); closeErr != nil && (*err == nil || errors.Is(*err, errFailedZipCodes)) {
+		*err = fmt.Errorf("error writing results: %w", closeErr)
+	}
+}
+
+func openInput(path string) (io.ReadCloser, error) {
+	if path == "-" {
+		return io.NopCloser(os.Stdin), nil
+	}
+
+	return os.Open(path)
+}
+
+// output buffers a results writer over a file, flushing everything on Close.
+type output struct {
+	bulkfile.WriterInterface
+	buffer *bufio.Writer
+	file   io.Closer
+}
+
+func openOutput(path string, format bulkfile.Format) (*output, error) {
+	if format == "" {
+		format = bulkfile.DetectFormat(path, bulkfile.FormatJSONL)
+	}
+
+	var file io.WriteCloser = nopWriteCloser{os.Stdout}
+	if path != "-" {
+		var err error
+		if file, err = os.Create(path); err != nil {
+			return nil, err
+		}
+	}
+
+	buffer := bufio.NewWriter(file)
+
+	writer, err := bulkfile.NewWriter(buffer, format)
+	if err != nil {
+		file.Close()
+		return nil, err
+	}
+
+	return &output{WriterInterface: writer, buffer: buffer, file: file}, nil
+}
+
+func (o *output) Close() error {
+	if err := o.Flush(); err != nil {
+		return err
+	}
+
+	if err := o.buffer.Flush(); err != nil {
+		return err
+	}
+
+	return o.file.Close()
+}
+
+type nopWriteCloser struct {
+	io.Writer
+}
+
+func (nopWriteCloser) Close() error {
+	return nil
+}
@@ -35,6 +35,9 @@ func main() {
 	}
 
 	deps.WebServer.Start()
+	if deps.AdminWebServer != nil {
+		deps.AdminWebServer.Start()
+	}
 
 	var wg sync.WaitGroup
 	wg.Add(1)
@@ -52,6 +55,18 @@ func main() {
 			log.Fatalf("error shutting webserver down: %s\n", err)
 		}
 
+		if deps.AdminWebServer != nil {
+			if err := deps.AdminWebServer.Shutdown(shutdownCtx); err != nil {
+				log.Fatalf("error shutting admin webserver down: %s\n", err)
+			}
+		}
+
+		for _, closer := range deps.Closers {
+			if err := closer.Close(); err != nil {
+				log.Printf("error closing dependency: %s\n", err)
+			}
+		}
+
 		if err := otelProviderShutdownFn(shutdownCtx); err != nil {
 			log.Fatalf("error shutting otel provider down: %s\n", err)
 		}
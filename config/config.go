@@ -3,15 +3,79 @@ package config
 import "github.com/spf13/viper"
 
 type Conf struct {
-	LogLevel                         string `mapstructure:"LOG_LEVEL"`
-	InputServiceWebServerPort        int    `mapstructure:"INPUT_SERVICE_WEB_SERVER_PORT"`
-	OrchestratorServiceWebServerPort int    `mapstructure:"ORCHESTRATOR_SERVICE_WEB_SERVER_PORT"`
-	HttpClientTimeout                int    `mapstructure:"HTTP_CLIENT_TIMEOUT_MS"`
-	ViaCepApiBaseUrl                 string `mapstructure:"VIACEP_API_BASE_URL"`
-	WeatherApiBaseUrl                string `mapstructure:"WEATHER_API_BASE_URL"`
-	WeatherApiKey                    string `mapstructure:"WEATHER_API_KEY"`
-	OrchestratorServiceHost          string `mapstructure:"ORCHESTRATOR_SERVICE_HOST"`
-	OtelCollectorURL                 string `mapstructure:"OTEL_COLLECTOR_URL"`
+	LogLevel                         string   `mapstructure:"LOG_LEVEL"`
+	InputServiceWebServerPort        int      `mapstructure:"INPUT_SERVICE_WEB_SERVER_PORT"`
+	OrchestratorServiceWebServerPort int      `mapstructure:"ORCHESTRATOR_SERVICE_WEB_SERVER_PORT"`
+	HttpClientTimeout                int      `mapstructure:"HTTP_CLIENT_TIMEOUT_MS"`
+	HttpClientRetryMaxAttempts       int      `mapstructure:"HTTP_CLIENT_RETRY_MAX_ATTEMPTS"`
+	HttpClientRetryBaseDelay         int      `mapstructure:"HTTP_CLIENT_RETRY_BASE_DELAY_MS"`
+	HttpClientRetryMaxDelay          int      `mapstructure:"HTTP_CLIENT_RETRY_MAX_DELAY_MS"`
+	HttpClientRetryJitter            float64  `mapstructure:"HTTP_CLIENT_RETRY_JITTER"`
+	HttpClientRetryStatusCodes       []int    `mapstructure:"HTTP_CLIENT_RETRY_STATUS_CODES"`
+	HttpClientBreakerFailureRatio    float64  `mapstructure:"HTTP_CLIENT_BREAKER_FAILURE_RATIO"`
+	HttpClientBreakerWindowSize      int      `mapstructure:"HTTP_CLIENT_BREAKER_WINDOW_SIZE"`
+	HttpClientBreakerCoolDown        int      `mapstructure:"HTTP_CLIENT_BREAKER_COOL_DOWN_MS"`
+	HttpClientMaxIdleConns           int      `mapstructure:"HTTP_CLIENT_MAX_IDLE_CONNS"`
+	HttpClientMaxIdleConnsPerHost    int      `mapstructure:"HTTP_CLIENT_MAX_IDLE_CONNS_PER_HOST"`
+	HttpClientMaxConnsPerHost        int      `mapstructure:"HTTP_CLIENT_MAX_CONNS_PER_HOST"`
+	HttpClientIdleConnTimeout        int      `mapstructure:"HTTP_CLIENT_IDLE_CONN_TIMEOUT_MS"`
+	HttpClientTLSHandshakeTimeout    int      `mapstructure:"HTTP_CLIENT_TLS_HANDSHAKE_TIMEOUT_MS"`
+	HttpClientResponseHeaderTimeout  int      `mapstructure:"HTTP_CLIENT_RESPONSE_HEADER_TIMEOUT_MS"`
+	HttpClientDisableHTTP2           bool     `mapstructure:"HTTP_CLIENT_DISABLE_HTTP2"`
+	HttpClientMaxResponseBodyBytes   int64    `mapstructure:"HTTP_CLIENT_MAX_RESPONSE_BODY_BYTES"`
+	ViaCepApiBaseUrl                 string   `mapstructure:"VIACEP_API_BASE_URL"`
+	ViaCepRateLimitRPS               float64  `mapstructure:"VIACEP_RATE_LIMIT_RPS"`
+	ViaCepRateLimitBurst             int      `mapstructure:"VIACEP_RATE_LIMIT_BURST"`
+	ViaCepRateLimitMode              string   `mapstructure:"VIACEP_RATE_LIMIT_MODE"`
+	ViaCepHedgeDelay                 int      `mapstructure:"VIACEP_HEDGE_DELAY_MS"`
+	ViaCepHedgePercentile            float64  `mapstructure:"VIACEP_HEDGE_PERCENTILE"`
+	ViaCepTimeout                    int      `mapstructure:"VIACEP_TIMEOUT_MS"`
+	BrasilApiBaseUrl                 string   `mapstructure:"BRASILAPI_BASE_URL"`
+	BrasilApiTimeout                 int      `mapstructure:"BRASILAPI_TIMEOUT_MS"`
+	OpenCepBaseUrl                   string   `mapstructure:"OPENCEP_BASE_URL"`
+	OpenCepTimeout                   int      `mapstructure:"OPENCEP_TIMEOUT_MS"`
+	LocationProviders                []string `mapstructure:"LOCATION_PROVIDERS"`
+	LocationCacheTTL                 int      `mapstructure:"LOCATION_CACHE_TTL_MS"`
+	LocationCacheNegativeTTL         int      `mapstructure:"LOCATION_CACHE_NEGATIVE_TTL_MS"`
+	LocationCacheMaxEntries          int      `mapstructure:"LOCATION_CACHE_MAX_ENTRIES"`
+	LocationCacheBackend             string   `mapstructure:"LOCATION_CACHE_BACKEND"`
+	LocationCacheFilePath            string   `mapstructure:"LOCATION_CACHE_FILE_PATH"`
+	LocationCacheCompactionInterval  int      `mapstructure:"LOCATION_CACHE_COMPACTION_INTERVAL_MS"`
+	ClimateCacheTTL                  int      `mapstructure:"CLIMATE_CACHE_TTL_MS"`
+	ClimateCacheStaleWhileRevalidate int      `mapstructure:"CLIMATE_CACHE_STALE_WHILE_REVALIDATE_MS"`
+	ClimateCacheStaleIfError         int      `mapstructure:"CLIMATE_CACHE_STALE_IF_ERROR_MS"`
+	ClimateCacheMaxEntries           int      `mapstructure:"CLIMATE_CACHE_MAX_ENTRIES"`
+	WeatherApiBaseUrl                string   `mapstructure:"WEATHER_API_BASE_URL"`
+	WeatherApiKey                    string   `mapstructure:"WEATHER_API_KEY"`
+	WeatherApiRateLimitRPS           float64  `mapstructure:"WEATHER_API_RATE_LIMIT_RPS"`
+	WeatherApiRateLimitBurst         int      `mapstructure:"WEATHER_API_RATE_LIMIT_BURST"`
+	WeatherApiRateLimitMode          string   `mapstructure:"WEATHER_API_RATE_LIMIT_MODE"`
+	WeatherApiTimeout                int      `mapstructure:"WEATHER_API_TIMEOUT_MS"`
+	OpenMeteoBaseUrl                 string   `mapstructure:"OPEN_METEO_BASE_URL"`
+	OpenMeteoGeocodingBaseUrl        string   `mapstructure:"OPEN_METEO_GEOCODING_BASE_URL"`
+	OpenMeteoTimeout                 int      `mapstructure:"OPEN_METEO_TIMEOUT_MS"`
+	WeatherProviders                 []string `mapstructure:"WEATHER_PROVIDERS"`
+	WeatherProvidersMode             string   `mapstructure:"WEATHER_PROVIDERS_MODE"`
+	WeatherConsensusMaxDeviation     float64  `mapstructure:"WEATHER_CONSENSUS_MAX_DEVIATION_C"`
+	ForecastMaxDays                  int      `mapstructure:"FORECAST_MAX_DAYS"`
+	ForecastMaxHours                 int      `mapstructure:"FORECAST_MAX_HOURS"`
+	GeocodingProvider                string   `mapstructure:"GEOCODING_PROVIDER"`
+	GeocodingCacheTTL                int      `mapstructure:"GEOCODING_CACHE_TTL_MS"`
+	GeocodingCacheMaxEntries         int      `mapstructure:"GEOCODING_CACHE_MAX_ENTRIES"`
+	OrchestratorServiceHost          string   `mapstructure:"ORCHESTRATOR_SERVICE_HOST"`
+	OrchestratorRateLimitRPS         float64  `mapstructure:"ORCHESTRATOR_RATE_LIMIT_RPS"`
+	OrchestratorRateLimitBurst       int      `mapstructure:"ORCHESTRATOR_RATE_LIMIT_BURST"`
+	OrchestratorRateLimitMode        string   `mapstructure:"ORCHESTRATOR_RATE_LIMIT_MODE"`
+	AdminWebServerPort               int      `mapstructure:"ADMIN_WEB_SERVER_PORT"`
+	AdminToken                       string   `mapstructure:"ADMIN_TOKEN"`
+	AdminWarmupConcurrency           int      `mapstructure:"ADMIN_WARMUP_CONCURRENCY"`
+	BatchMaxSize                     int      `mapstructure:"BATCH_MAX_SIZE"`
+	BatchConcurrency                 int      `mapstructure:"BATCH_CONCURRENCY"`
+	BatchTimeout                     int      `mapstructure:"BATCH_TIMEOUT_MS"`
+	StreamPollInterval               int      `mapstructure:"STREAM_POLL_INTERVAL_MS"`
+	StreamHeartbeatInterval          int      `mapstructure:"STREAM_HEARTBEAT_INTERVAL_MS"`
+	StreamMaxSubscribers             int      `mapstructure:"STREAM_MAX_SUBSCRIBERS"`
+	OtelCollectorURL                 string   `mapstructure:"OTEL_COLLECTOR_URL"`
 }
 
 func LoadConfig(path string) (*Conf, error) {
@@ -1,49 +1,63 @@
 package entities
 
-type ClimateLocation struct {
-	Name           string  `json:"name"`
-	Region         string  `json:"region"`
-	Country        string  `json:"country"`
-	Lat            float64 `json:"lat"`
-	Lon            float64 `json:"lon"`
-	TzID           string  `json:"tz_id"`
-	LocaltimeEpoch int     `json:"localtime_epoch"`
-	Localtime      string  `json:"localtime"`
+import "time"
+
+// Climate is the provider-neutral weather reading; each weather provider translates its own
+// payload into it.
+type Climate struct {
+	Provider  string            `json:"provider"`
+	Location  ClimateLocation   `json:"location"`
+	Current   ClimateData       `json:"current"`
+	Consensus *ClimateConsensus `json:"consensus,omitempty"`
+	Stale     bool              `json:"-"`
 }
 
-type ClimateCondition struct {
-	Text string `json:"text"`
-	Icon string `json:"icon"`
-	Code int    `json:"code"`
+// ClimateConsensus explains a reading agreed between several providers: the median of the
+// readings that were not discarded as outliers, and how far apart those readings were.
+type ClimateConsensus struct {
+	Readings []ClimateReading `json:"readings"`
+	SpreadC  float64          `json:"spread_c"`
 }
 
-type ClimateData struct {
-	LastUpdatedEpoch int              `json:"last_updated_epoch"`
-	LastUpdated      string           `json:"last_updated"`
-	TempC            float64          `json:"temp_c"`
-	TempF            float64          `json:"temp_f"`
-	IsDay            int              `json:"is_day"`
-	Condition        ClimateCondition `json:"condition"`
-	WindMph          float64          `json:"wind_mph"`
-	WindKph          float64          `json:"wind_kph"`
-	WindDegree       int              `json:"wind_degree"`
-	WindDir          string           `json:"wind_dir"`
-	PressureMb       float64          `json:"pressure_mb"`
-	PressureIn       float64          `json:"pressure_in"`
-	PrecipMm         float64          `json:"precip_mm"`
-	PrecipIn         float64          `json:"precip_in"`
-	Humidity         int              `json:"humidity"`
-	Cloud            int              `json:"cloud"`
-	FeelslikeC       float64          `json:"feelslike_c"`
-	FeelslikeF       float64          `json:"feelslike_f"`
-	VisKm            float64          `json:"vis_km"`
-	VisMiles         float64          `json:"vis_miles"`
-	Uv               float64          `json:"uv"`
-	GustMph          float64          `json:"gust_mph"`
-	GustKph          float64          `json:"gust_kph"`
+type ClimateReading struct {
+	Provider string  `json:"provider"`
+	TempC    float64 `json:"temp_c,omitempty"`
+	Outlier  bool    `json:"outlier,omitempty"`
+	Error    string  `json:"error,omitempty"`
 }
 
-type Climate struct {
-	Location ClimateLocation `json:"location"`
-	Current  ClimateData     `json:"current"`
+// ClimateQuery identifies where to read the weather. Providers query by Coordinates when they
+// are resolved, and fall back to searching "City, Region" otherwise.
+type ClimateQuery struct {
+	City        string
+	Region      string
+	Coordinates *Coordinates
+}
+
+type Coordinates struct {
+	Latitude  float64 `json:"lat"`
+	Longitude float64 `json:"lon"`
+}
+
+type ClimateLocation struct {
+	Name      string  `json:"name"`
+	Region    string  `json:"region"`
+	Country   string  `json:"country"`
+	Latitude  float64 `json:"lat"`
+	Longitude float64 `json:"lon"`
+	TimeZone  string  `json:"tz_id"`
+}
+
+type ClimateData struct {
+	ObservedAt time.Time `json:"observed_at"`
+	TempC      float64   `json:"temp_c"`
+	FeelsLikeC float64   `json:"feelslike_c"`
+	Humidity   int       `json:"humidity"`
+	WindKph    float64   `json:"wind_kph"`
+	WindDegree int       `json:"wind_degree"`
+	PressureMb float64   `json:"pressure_mb"`
+	PrecipMm   float64   `json:"precip_mm"`
+	Uv         float64   `json:"uv"`
+	Condition  string    `json:"condition"`
+	IsDay      bool      `json:"is_day"`
 }
@@ -0,0 +1,37 @@
+package dto
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/wellalencarweb/otel-lab-challenge/internal/pkg/customerrors"
+)
+
+type BatchInput struct {
+	Zipcodes []string `json:"ceps"`
+}
+
+func (i BatchInput) Validate(maxSize int) error {
+	if len(i.Zipcodes) == 0 || len(i.Zipcodes) > maxSize {
+		message := fmt.Sprintf("invalid batch, must have between 1 and %d ceps", maxSize)
+
+		return &customerrors.ValidationError{
+			Err:     errors.New(message),
+			Message: message,
+			Reasons: []string{fmt.Sprintf("batch has %d ceps", len(i.Zipcodes))},
+		}
+	}
+
+	return nil
+}
+
+// BatchOutput answers each distinct zipcode once, in the order they were first requested.
+// Failed zipcodes carry their error instead of failing the whole batch.
+type BatchOutput struct {
+	Total      int                 `json:"total"`
+	Resolved   int                 `json:"resolved"`
+	Failed     int                 `json:"failed"`
+	Duplicates int                 `json:"duplicates"`
+	DurationMs int64               `json:"duration_ms"`
+	Results    []BulkResolveResult `json:"results"`
+}
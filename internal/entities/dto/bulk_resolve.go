@@ -0,0 +1,30 @@
+package dto
+
+import "time"
+
+// BulkResolveResult is one line of the bulk resolver output. Failed zipcodes carry Error and
+// no temperatures.
+type BulkResolveResult struct {
+	Zipcode string `json:"zipcode"`
+	*GetTemperaturesByZipCodeOutput
+	State      string `json:"state,omitempty"`
+	Error      string `json:"error,omitempty"`
+	DurationMs int64  `json:"duration_ms"`
+}
+
+type BulkResolveSummary struct {
+	Total      int           `json:"total"`
+	Resolved   int           `json:"resolved"`
+	Failed     int           `json:"failed"`
+	Duplicates int           `json:"duplicates"`
+	Duration   time.Duration `json:"duration"`
+}
+
+// PerSecond is the throughput of the run, duplicates included.
+func (s BulkResolveSummary) PerSecond() float64 {
+	if s.Duration <= 0 {
+		return 0
+	}
+
+	return float64(s.Total) / s.Duration.Seconds()
+}
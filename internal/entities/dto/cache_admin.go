@@ -0,0 +1,21 @@
+package dto
+
+type CacheWarmupInput struct {
+	Zipcodes []string `json:"zipcodes"`
+}
+
+type CacheWarmupError struct {
+	Zipcode string `json:"zipcode"`
+	Message string `json:"message"`
+}
+
+type CacheWarmupOutput struct {
+	Requested int                `json:"requested"`
+	Warmed    int                `json:"warmed"`
+	Failed    int                `json:"failed"`
+	Errors    []CacheWarmupError `json:"errors,omitempty"`
+}
+
+type CachePurgeOutput struct {
+	Purged int `json:"purged"`
+}
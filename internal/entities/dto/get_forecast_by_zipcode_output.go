@@ -0,0 +1,37 @@
+package dto
+
+type GetForecastByZipCodeOutput struct {
+	City      string              `json:"city"`
+	Latitude  float64             `json:"lat,omitempty"`
+	Longitude float64             `json:"lon,omitempty"`
+	Days      []ForecastDayOutput `json:"days"`
+}
+
+type ForecastDayOutput struct {
+	Date      string            `json:"date"`
+	Min       TemperatureOutput `json:"min"`
+	Max       TemperatureOutput `json:"max"`
+	Avg       TemperatureOutput `json:"avg"`
+	Condition string            `json:"condition"`
+}
+
+type TemperatureOutput struct {
+	Celcius    float32 `json:"temp_C"`
+	Fahrenheit float32 `json:"temp_F"`
+	Kelvin     float32 `json:"temp_K"`
+}
+
+type GetHourlyForecastByZipCodeOutput struct {
+	City      string               `json:"city"`
+	Latitude  float64              `json:"lat,omitempty"`
+	Longitude float64              `json:"lon,omitempty"`
+	TimeZone  string               `json:"tz_id"`
+	Hours     []ForecastHourOutput `json:"hours"`
+}
+
+// ForecastHourOutput carries an ISO-8601 time with the offset of the time zone it is in.
+type ForecastHourOutput struct {
+	Time string `json:"time"`
+	TemperatureOutput
+	Condition string `json:"condition"`
+}
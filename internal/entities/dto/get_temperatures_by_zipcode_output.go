@@ -1,8 +1,133 @@
 package dto
 
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/wellalencarweb/otel-lab-challenge/internal/entities"
+)
+
+// Optional fields of GetTemperaturesByZipCodeOutput, answered only when requested through the
+// fields (or expand) query parameter so the default response stays as it always was.
+const (
+	FieldFeelsLike = "feelslike"
+	FieldHumidity  = "humidity"
+	FieldWind      = "wind"
+	FieldPressure  = "pressure"
+	FieldUv        = "uv"
+	FieldCondition = "condition"
+	FieldAddress   = "address"
+)
+
+var TemperatureFields = []string{
+	FieldFeelsLike, FieldHumidity, FieldWind, FieldPressure, FieldUv, FieldCondition, FieldAddress,
+}
+
 type GetTemperaturesByZipCodeOutput struct {
 	City       string  `json:"city"`
 	Celcius    float32 `json:"temp_C"`
 	Fahrenheit float32 `json:"temp_F"`
 	Kelvin     float32 `json:"temp_K"`
+	Latitude   float64 `json:"lat,omitempty"`
+	Longitude  float64 `json:"lon,omitempty"`
+	Stale      bool    `json:"stale,omitempty"`
+
+	Consensus *TemperatureConsensusOutput `json:"consensus,omitempty"`
+
+	FeelsLike  *TemperatureOutput `json:"feelslike,omitempty"`
+	Humidity   *int               `json:"humidity,omitempty"`
+	Wind       *WindOutput        `json:"wind,omitempty"`
+	PressureMb *float32           `json:"pressure_mb,omitempty"`
+	Uv         *float32           `json:"uv,omitempty"`
+	Condition  string             `json:"condition,omitempty"`
+	Address    *AddressOutput     `json:"address,omitempty"`
+}
+
+type WindOutput struct {
+	SpeedKph float32 `json:"speed_kph"`
+	Degree   int     `json:"degree"`
+}
+
+type AddressOutput struct {
+	Zipcode      string `json:"zipcode"`
+	Street       string `json:"street,omitempty"`
+	Complement   string `json:"complement,omitempty"`
+	Neighborhood string `json:"neighborhood,omitempty"`
+	City         string `json:"city"`
+	State        string `json:"state"`
+}
+
+type TemperatureConsensusOutput struct {
+	Spread   float32                    `json:"spread_C"`
+	Readings []TemperatureReadingOutput `json:"readings"`
+}
+
+type TemperatureReadingOutput struct {
+	Provider string   `json:"provider"`
+	Celcius  *float32 `json:"temp_C,omitempty"`
+	Outlier  bool     `json:"outlier,omitempty"`
+	Error    string   `json:"error,omitempty"`
+}
+
+// NewTemperatureConsensusOutput returns nil for readings that did not come from a consensus.
+func NewTemperatureConsensusOutput(consensus *entities.ClimateConsensus) *TemperatureConsensusOutput {
+	if consensus == nil {
+		return nil
+	}
+
+	output := &TemperatureConsensusOutput{
+		Spread:   float32(consensus.SpreadC),
+		Readings: make([]TemperatureReadingOutput, len(consensus.Readings)),
+	}
+
+	for i, reading := range consensus.Readings {
+		output.Readings[i] = TemperatureReadingOutput{
+			Provider: reading.Provider,
+			Outlier:  reading.Outlier,
+			Error:    reading.Error,
+		}
+
+		// a failed provider has no temperature, unlike a reading of exactly 0 °C
+		if reading.Error == "" {
+			celcius := float32(reading.TempC)
+			output.Readings[i].Celcius = &celcius
+		}
+	}
+
+	return output
+}
+
+// ParseTemperatureFields reads comma-separated lists of optional fields, ignoring blanks and
+// repetitions, and rejects fields it does not know.
+func ParseTemperatureFields(lists ...string) ([]string, error) {
+	var fields []string
+
+	for _, list := range lists {
+		for _, field := range strings.Split(list, ",") {
+			field = strings.ToLower(strings.TrimSpace(field))
+			if field == "" || slices.Contains(fields, field) {
+				continue
+			}
+
+			if !slices.Contains(TemperatureFields, field) {
+				return nil, fmt.Errorf("invalid field [%s], must be one of %s", field, strings.Join(TemperatureFields, ", "))
+			}
+
+			fields = append(fields, field)
+		}
+	}
+
+	return fields, nil
+}
+
+func NewAddressOutput(location *entities.Location) *AddressOutput {
+	return &AddressOutput{
+		Zipcode:      location.Zipcode,
+		Street:       location.AddressLine1,
+		Complement:   location.AddressLine2,
+		Neighborhood: location.Neighborhood,
+		City:         location.City,
+		State:        location.State,
+	}
 }
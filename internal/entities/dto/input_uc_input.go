@@ -2,12 +2,14 @@ package dto
 
 import (
 	"errors"
+	"strings"
 
 	"github.com/wellalencarweb/otel-lab-challenge/internal/pkg/customerrors"
 )
 
 type InputUCInput struct {
-	Zipcode string `json:"cep"`
+	Zipcode string   `json:"cep"`
+	Fields  []string `json:"fields,omitempty"`
 }
 
 func (i InputUCInput) Validate() error {
@@ -19,5 +21,13 @@ func (i InputUCInput) Validate() error {
 		}
 	}
 
+	if _, err := ParseTemperatureFields(i.Fields...); err != nil {
+		return &customerrors.ValidationError{
+			Err:     err,
+			Message: err.Error(),
+			Reasons: []string{"fields must be some of " + strings.Join(TemperatureFields, ", ")},
+		}
+	}
+
 	return nil
 }
@@ -0,0 +1,26 @@
+package entities
+
+import "time"
+
+// Forecast is the provider-neutral forecast, either daily, starting today in the location's
+// time zone, or hourly, starting at the current hour.
+type Forecast struct {
+	Provider string          `json:"provider"`
+	Location ClimateLocation `json:"location"`
+	Days     []ForecastDay   `json:"days,omitempty"`
+	Hours    []ForecastHour  `json:"hours,omitempty"`
+}
+
+type ForecastDay struct {
+	Date      string  `json:"date"`
+	MinTempC  float64 `json:"mintemp_c"`
+	MaxTempC  float64 `json:"maxtemp_c"`
+	AvgTempC  float64 `json:"avgtemp_c"`
+	Condition string  `json:"condition"`
+}
+
+type ForecastHour struct {
+	Time      time.Time `json:"time"`
+	TempC     float64   `json:"temp_c"`
+	Condition string    `json:"condition"`
+}
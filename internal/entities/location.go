@@ -1,5 +1,7 @@
 package entities
 
+// Location keeps ViaCEP's field names as its JSON format, which is also the format of the
+// persisted location cache; providers translate their own payloads into it.
 type Location struct {
 	Zipcode      string `json:"cep"`
 	AddressLine1 string `json:"logradouro"`
@@ -11,4 +13,5 @@ type Location struct {
 	GIACode      string `json:"gia"`
 	AreaCode     string `json:"ddd"`
 	SIAFICode    string `json:"siafi"`
+	Provider     string `json:"provider,omitempty"`
 }
@@ -0,0 +1,134 @@
+package bulkfile
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/wellalencarweb/otel-lab-challenge/internal/entities/dto"
+)
+
+type BulkFileTestSuite struct {
+	suite.Suite
+}
+
+func TestBulkFile(t *testing.T) {
+	suite.Run(t, new(BulkFileTestSuite))
+}
+
+func (s *BulkFileTestSuite) read(input string, format Format) ([]string, error) {
+	out := make(chan string)
+	errs := make(chan error, 1)
+
+	go func() {
+		errs <- ReadZipCodes(context.Background(), strings.NewReader(input), format, out)
+	}()
+
+	var zipCodes []string
+	for zipCode := range out {
+		zipCodes = append(zipCodes, zipCode)
+	}
+
+	return zipCodes, <-errs
+}
+
+func (s *BulkFileTestSuite) TestDetectFormat() {
+	s.Equal(FormatCSV, DetectFormat("ceps.CSV", FormatText))
+	s.Equal(FormatJSONL, DetectFormat("ceps.ndjson", FormatText))
+	s.Equal(FormatText, DetectFormat("-", FormatText))
+	s.Equal(FormatJSONL, DetectFormat("results", FormatJSONL))
+}
+
+func (s *BulkFileTestSuite) TestReadZipCodes() {
+	s.Run("should read text skipping blank lines and comments", func() {
+		zipCodes, err := s.read("# top ceps\n22021001\n\n 01001-000 \n", FormatText)
+		s.Nil(err)
+		s.Equal([]string{"22021001", "01001-000"}, zipCodes)
+	})
+
+	s.Run("should read the cep column of csv files with a header", func() {
+		zipCodes, err := s.read("customer,cep\nacme,22021001\nglobex,01001000\n", FormatCSV)
+		s.Nil(err)
+		s.Equal([]string{"22021001", "01001000"}, zipCodes)
+	})
+
+	s.Run("should read the first column of csv files without a header", func() {
+		zipCodes, err := s.read("22021001,acme\n01001000,globex\n", FormatCSV)
+		s.Nil(err)
+		s.Equal([]string{"22021001", "01001000"}, zipCodes)
+	})
+
+	s.Run("should read jsonl strings and objects", func() {
+		zipCodes, err := s.read("\"22021001\"\n{\"cep\":\"01001000\"}\n{\"zipcode\":\"69900000\",\"customer\":\"acme\"}\n", FormatJSONL)
+		s.Nil(err)
+		s.Equal([]string{"22021001", "01001000", "69900000"}, zipCodes)
+	})
+
+	s.Run("should point at invalid jsonl lines", func() {
+		zipCodes, err := s.read("\"22021001\"\n{\"customer\":\"acme\"}\n", FormatJSONL)
+		s.EqualError(err, "line 2: missing cep field")
+		s.Equal([]string{"22021001"}, zipCodes)
+	})
+}
+
+func (s *BulkFileTestSuite) TestWriter() {
+	results := []dto.BulkResolveResult{
+		{
+			Zipcode: "22021001",
+			GetTemperaturesByZipCodeOutput: &dto.GetTemperaturesByZipCodeOutput{
+				City:       "Rio de Janeiro",
+				Celcius:    30,
+				Fahrenheit: 86,
+				Kelvin:     303.15,
+				Latitude:   -22.9068,
+				Longitude:  -43.1729,
+			},
+			State:      "RJ",
+			DurationMs: 120,
+		},
+		{Zipcode: "99999999", Error: "can not find zipcode", DurationMs: 40},
+	}
+
+	s.Run("should write jsonl", func() {
+		var buffer bytes.Buffer
+		writer, err := NewWriter(&buffer, FormatJSONL)
+		s.Require().NoError(err)
+
+		for _, result := range results {
+			s.Nil(writer.Write(result))
+		}
+		s.Nil(writer.Flush())
+
+		s.Equal(
+			"{\"zipcode\":\"22021001\",\"city\":\"Rio de Janeiro\",\"temp_C\":30,\"temp_F\":86,\"temp_K\":303.15,\"lat\":-22.9068,\"lon\":-43.1729,\"state\":\"RJ\",\"duration_ms\":120}\n"+
+				"{\"zipcode\":\"99999999\",\"error\":\"can not find zipcode\",\"duration_ms\":40}\n",
+			buffer.String(),
+		)
+	})
+
+	s.Run("should write csv", func() {
+		var buffer bytes.Buffer
+		writer, err := NewWriter(&buffer, FormatCSV)
+		s.Require().NoError(err)
+
+		for _, result := range results {
+			s.Nil(writer.Write(result))
+		}
+		s.Nil(writer.Flush())
+
+		s.Equal(
+			"zipcode,city,state,temp_C,temp_F,temp_K,lat,lon,stale,error,duration_ms\n"+
+				"22021001,Rio de Janeiro,RJ,30,86,303.15,-22.9068,-43.1729,false,,120\n"+
+				"99999999,,,,,,,,,can not find zipcode,40\n",
+			buffer.String(),
+		)
+	})
+
+	s.Run("should not write text", func() {
+		_, err := NewWriter(&bytes.Buffer{}, FormatText)
+		s.EqualError(err, "unsupported output format [text]")
+	})
+}
@@ -0,0 +1,189 @@
+package bulkfile
+
+import (
+	"bufio"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+	"unicode"
+)
+
+type Format string
+
+const (
+	FormatText  Format = "text"
+	FormatCSV   Format = "csv"
+	FormatJSONL Format = "jsonl"
+)
+
+var zipCodeColumns = []string{"cep", "zipcode", "zip_code"}
+
+// DetectFormat picks the format from the file extension, falling back when the path is
+// stdin ("-") or the extension is unknown.
+func DetectFormat(path string, fallback Format) Format {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".csv":
+		return FormatCSV
+	case ".jsonl", ".ndjson":
+		return FormatJSONL
+	case ".txt":
+		return FormatText
+	default:
+		return fallback
+	}
+}
+
+// ReadZipCodes sends every zipcode found in r to out, closing it once r is exhausted, ctx is
+// done or reading fails.
+//
+// Text input has one zipcode per line, skipping blank lines and lines starting with "#". CSV
+// input takes the cep/zipcode column when the first row is a header, or the first column
+// otherwise. JSONL input has either a JSON string or an object with a cep/zipcode field per line.
+func ReadZipCodes(ctx context.Context, r io.Reader, format Format, out chan<- string) error {
+	defer close(out)
+
+	send := func(zipCode string) error {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case out <- zipCode:
+			return nil
+		}
+	}
+
+	switch format {
+	case FormatText:
+		return readText(r, send)
+	case FormatCSV:
+		return readCSV(r, send)
+	case FormatJSONL:
+		return readJSONL(r, send)
+	default:
+		return fmt.Errorf("unknown input format [%s]", format)
+	}
+}
+
+func readText(r io.Reader, send func(string) error) error {
+	scanner := bufio.NewScanner(r)
+
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		if err := send(line); err != nil {
+			return err
+		}
+	}
+
+	return scanner.Err()
+}
+
+func readCSV(r io.Reader, send func(string) error) error {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	column := 0
+	first := true
+
+	for {
+		row, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		if first {
+			first = false
+
+			if index, ok := headerColumn(row); ok {
+				column = index
+				continue
+			}
+		}
+
+		if column >= len(row) || strings.TrimSpace(row[column]) == "" {
+			continue
+		}
+
+		if err := send(strings.TrimSpace(row[column])); err != nil {
+			return err
+		}
+	}
+}
+
+// headerColumn tells a header row apart from data: it either names the zipcode column or has
+// no digits at all in its first cell.
+func headerColumn(row []string) (int, bool) {
+	for i, cell := range row {
+		for _, name := range zipCodeColumns {
+			if strings.EqualFold(strings.TrimSpace(cell), name) {
+				return i, true
+			}
+		}
+	}
+
+	if len(row) > 0 && strings.IndexFunc(row[0], unicode.IsDigit) < 0 {
+		return 0, true
+	}
+
+	return 0, false
+}
+
+func readJSONL(r io.Reader, send func(string) error) error {
+	scanner := bufio.NewScanner(r)
+	number := 0
+
+	for scanner.Scan() {
+		number++
+
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+
+		zipCode, err := parseJSONLine([]byte(line))
+		if err != nil {
+			return fmt.Errorf("line %d: %w", number, err)
+		}
+
+		if err := send(zipCode); err != nil {
+			return err
+		}
+	}
+
+	return scanner.Err()
+}
+
+func parseJSONLine(line []byte) (string, error) {
+	var zipCode string
+	if err := json.Unmarshal(line, &zipCode); err == nil {
+		return zipCode, nil
+	}
+
+	var object map[string]json.RawMessage
+	if err := json.Unmarshal(line, &object); err != nil {
+		return "", err
+	}
+
+	for _, name := range zipCodeColumns {
+		if value, ok := object[name]; ok {
+			if err := json.Unmarshal(value, &zipCode); err != nil {
+				return "", fmt.Errorf("field [%s] must be a string", name)
+			}
+
+			return zipCode, nil
+		}
+	}
+
+	return "", errors.New("missing cep field")
+}
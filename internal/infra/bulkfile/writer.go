@@ -0,0 +1,77 @@
+package bulkfile
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+
+	"github.com/wellalencarweb/otel-lab-challenge/internal/entities/dto"
+)
+
+var csvHeader = []string{"zipcode", "city", "state", "temp_C", "temp_F", "temp_K", "lat", "lon", "stale", "error", "duration_ms"}
+
+type WriterInterface interface {
+	Write(result dto.BulkResolveResult) error
+	Flush() error
+}
+
+// NewWriter writes results as JSONL or CSV. The CSV header is written right away, so an empty
+// run still produces a well-formed file.
+func NewWriter(w io.Writer, format Format) (WriterInterface, error) {
+	switch format {
+	case FormatJSONL:
+		return &jsonlWriter{encoder: json.NewEncoder(w)}, nil
+	case FormatCSV:
+		writer := csv.NewWriter(w)
+		if err := writer.Write(csvHeader); err != nil {
+			return nil, err
+		}
+
+		return &csvWriter{writer: writer}, nil
+	default:
+		return nil, fmt.Errorf("unsupported output format [%s]", format)
+	}
+}
+
+type jsonlWriter struct {
+	encoder *json.Encoder
+}
+
+func (w *jsonlWriter) Write(result dto.BulkResolveResult) error {
+	return w.encoder.Encode(result)
+}
+
+func (w *jsonlWriter) Flush() error {
+	return nil
+}
+
+type csvWriter struct {
+	writer *csv.Writer
+}
+
+func (w *csvWriter) Write(result dto.BulkResolveResult) error {
+	row := []string{result.Zipcode, "", result.State, "", "", "", "", "", "", result.Error, strconv.FormatInt(result.DurationMs, 10)}
+
+	if temperatures := result.GetTemperaturesByZipCodeOutput; temperatures != nil {
+		row[1] = temperatures.City
+		row[3] = formatFloat(temperatures.Celcius)
+		row[4] = formatFloat(temperatures.Fahrenheit)
+		row[5] = formatFloat(temperatures.Kelvin)
+		row[6] = strconv.FormatFloat(temperatures.Latitude, 'f', -1, 64)
+		row[7] = strconv.FormatFloat(temperatures.Longitude, 'f', -1, 64)
+		row[8] = strconv.FormatBool(temperatures.Stale)
+	}
+
+	return w.writer.Write(row)
+}
+
+func (w *csvWriter) Flush() error {
+	w.writer.Flush()
+	return w.writer.Error()
+}
+
+func formatFloat(value float32) string {
+	return strconv.FormatFloat(float64(value), 'f', -1, 32)
+}
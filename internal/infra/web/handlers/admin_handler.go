@@ -0,0 +1,296 @@
+package handlers
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+
+	"github.com/go-chi/chi/v5"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/wellalencarweb/otel-lab-challenge/internal/entities/dto"
+	"github.com/wellalencarweb/otel-lab-challenge/internal/pkg/cache"
+	"github.com/wellalencarweb/otel-lab-challenge/internal/pkg/responsehandler"
+	"github.com/wellalencarweb/otel-lab-challenge/internal/usecases/climate"
+	"github.com/wellalencarweb/otel-lab-challenge/internal/usecases/location"
+)
+
+const AdminTokenHeader = "X-Admin-Token"
+
+// Names the orchestrator caches are registered under, and looked up by in the admin routes.
+const (
+	// LocationsCache keys are zipcodes normalized with location.CacheKey.
+	LocationsCache = "locations"
+	// ClimatesCache and GeocodingCache keys are "city|uf" normalized with climate.CacheKey.
+	ClimatesCache  = "climates"
+	GeocodingCache = "geocoding"
+)
+
+type WebAdminHandlerInterface interface {
+	Authorize(next http.HandlerFunc) http.HandlerFunc
+	GetCacheStats(w http.ResponseWriter, r *http.Request)
+	GetCacheEntry(w http.ResponseWriter, r *http.Request)
+	PurgeCacheEntry(w http.ResponseWriter, r *http.Request)
+	PurgeCacheEntries(w http.ResponseWriter, r *http.Request)
+	WarmUpCaches(w http.ResponseWriter, r *http.Request)
+}
+
+// WebAdminHandler exposes the orchestrator caches, keyed by name, for inspection and purging,
+// and warms them up by running zipcodes through the same use cases the public API uses.
+type WebAdminHandler struct {
+	ResponseHandler              responsehandler.WebResponseHandlerInterface
+	Token                        string
+	Caches                       map[string]cache.Inspector
+	FindLocationByZipCodeUseCase location.FindByZipCodeUseCaseInterface
+	FindClimateByCityNameUseCase climate.FindByCityNameUseCaseInterface
+	WarmupConcurrency            int
+	WarmupMaxSize                int
+	Tracer                       trace.Tracer
+}
+
+func NewWebAdminHandler(
+	rh responsehandler.WebResponseHandlerInterface,
+	token string,
+	caches map[string]cache.Inspector,
+	findByZipCodeUC location.FindByZipCodeUseCaseInterface,
+	findByCityNameUC climate.FindByCityNameUseCaseInterface,
+	warmupConcurrency int,
+	warmupMaxSize int,
+	tracer trace.Tracer,
+) *WebAdminHandler {
+	if warmupMaxSize <= 0 {
+		warmupMaxSize = DefaultBatchMaxSize
+	}
+
+	return &WebAdminHandler{
+		ResponseHandler:              rh,
+		Token:                        token,
+		Caches:                       caches,
+		FindLocationByZipCodeUseCase: findByZipCodeUC,
+		FindClimateByCityNameUseCase: findByCityNameUC,
+		WarmupConcurrency:            max(warmupConcurrency, 1),
+		WarmupMaxSize:                warmupMaxSize,
+		Tracer:                       tracer,
+	}
+}
+
+// Authorize only lets through requests carrying the admin token, either as a bearer token or
+// in the X-Admin-Token header. An empty token rejects every request.
+func (h *WebAdminHandler) Authorize(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		token := r.Header.Get(AdminTokenHeader)
+		if bearer, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer "); ok {
+			token = bearer
+		}
+
+		if h.Token == "" || subtle.ConstantTimeCompare([]byte(token), []byte(h.Token)) != 1 {
+			h.ResponseHandler.RespondWithError(w, http.StatusUnauthorized, errors.New("invalid admin token"))
+			return
+		}
+
+		next(w, r)
+	}
+}
+
+func (h *WebAdminHandler) GetCacheStats(w http.ResponseWriter, r *http.Request) {
+	stats := make(map[string]cache.Stats, len(h.Caches))
+	for name, inspector := range h.Caches {
+		stats[name] = inspector.Stats()
+	}
+
+	h.ResponseHandler.Respond(w, http.StatusOK, stats)
+}
+
+func (h *WebAdminHandler) GetCacheEntry(w http.ResponseWriter, r *http.Request) {
+	inspector, key, err := h.resolveKey(r)
+	if err != nil {
+		h.ResponseHandler.RespondWithError(w, http.StatusNotFound, err)
+		return
+	}
+
+	entry, ok := inspector.Inspect(key)
+	if !ok {
+		h.ResponseHandler.RespondWithError(w, http.StatusNotFound, errors.New("key not found"))
+		return
+	}
+
+	h.ResponseHandler.Respond(w, http.StatusOK, entry)
+}
+
+func (h *WebAdminHandler) PurgeCacheEntry(w http.ResponseWriter, r *http.Request) {
+	inspector, key, err := h.resolveKey(r)
+	if err != nil {
+		h.ResponseHandler.RespondWithError(w, http.StatusNotFound, err)
+		return
+	}
+
+	if !inspector.Purge(key) {
+		h.ResponseHandler.RespondWithError(w, http.StatusNotFound, errors.New("key not found"))
+		return
+	}
+
+	h.ResponseHandler.Respond(w, http.StatusOK, dto.CachePurgeOutput{Purged: 1})
+}
+
+// PurgeCacheEntries purges every key starting with the prefix query parameter. The parameter
+// is required, so an empty prefix has to be asked for explicitly to flush the whole cache.
+func (h *WebAdminHandler) PurgeCacheEntries(w http.ResponseWriter, r *http.Request) {
+	name := chi.URLParam(r, "cache")
+	inspector, ok := h.Caches[name]
+	if !ok {
+		h.ResponseHandler.RespondWithError(w, http.StatusNotFound, errors.New("cache not found"))
+		return
+	}
+
+	prefix, ok := r.URL.Query()["prefix"]
+	if !ok {
+		h.ResponseHandler.RespondWithError(w, http.StatusBadRequest, errors.New("prefix is required"))
+		return
+	}
+
+	h.ResponseHandler.Respond(w, http.StatusOK, dto.CachePurgeOutput{Purged: inspector.PurgePrefix(normalizeKey(name, prefix[0]))})
+}
+
+// WarmUpCaches takes up to WarmupMaxSize zipcodes, like a batch, and stops starting lookups once
+// the client goes away.
+func (h *WebAdminHandler) WarmUpCaches(w http.ResponseWriter, r *http.Request) {
+	ctx, span := h.Tracer.Start(r.Context(), "cache-warmup")
+	defer span.End()
+
+	var input dto.CacheWarmupInput
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		span.SetStatus(codes.Error, "invalid body")
+		span.RecordError(err)
+
+		h.ResponseHandler.RespondWithError(w, http.StatusBadRequest, errors.New("invalid body"))
+		return
+	}
+
+	if len(input.Zipcodes) > h.WarmupMaxSize {
+		err := fmt.Errorf("invalid body, must have at most %d zipcodes", h.WarmupMaxSize)
+
+		span.SetStatus(codes.Error, "too many zipcodes")
+		span.RecordError(err)
+
+		h.ResponseHandler.RespondWithError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	zipCodes := dedupe(input.Zipcodes)
+	span.SetAttributes(attribute.Int("warmup.zipcodes", len(zipCodes)))
+
+	output := dto.CacheWarmupOutput{Requested: len(zipCodes)}
+
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	semaphore := make(chan struct{}, h.WarmupConcurrency)
+
+warmup:
+	for _, zipCode := range zipCodes {
+		// a free slot would win the select half of the time, so a gone client is checked first
+		if ctx.Err() != nil {
+			break
+		}
+
+		select {
+		case <-ctx.Done():
+			break warmup
+		case semaphore <- struct{}{}:
+		}
+
+		wg.Add(1)
+
+		go func(zipCode string) {
+			defer wg.Done()
+			defer func() { <-semaphore }()
+
+			err := h.warmUp(ctx, zipCode)
+
+			mu.Lock()
+			defer mu.Unlock()
+
+			if err != nil {
+				output.Failed++
+				output.Errors = append(output.Errors, dto.CacheWarmupError{Zipcode: zipCode, Message: err.Error()})
+				return
+			}
+
+			output.Warmed++
+		}(zipCode)
+	}
+
+	wg.Wait()
+
+	span.SetAttributes(attribute.Int("warmup.warmed", output.Warmed), attribute.Int("warmup.failed", output.Failed))
+
+	h.ResponseHandler.Respond(w, http.StatusOK, output)
+}
+
+func (h *WebAdminHandler) warmUp(ctx context.Context, zipCode string) error {
+	if err := validateInput(zipCode); err != nil {
+		return err
+	}
+
+	location, err := h.FindLocationByZipCodeUseCase.Execute(ctx, zipCode)
+	if err != nil {
+		return err
+	}
+	if location.City == "" {
+		return errors.New("zipcode not found")
+	}
+
+	_, err = h.FindClimateByCityNameUseCase.Execute(ctx, location.City, location.State)
+
+	return err
+}
+
+func (h *WebAdminHandler) resolveKey(r *http.Request) (cache.Inspector, string, error) {
+	name := chi.URLParam(r, "cache")
+	inspector, ok := h.Caches[name]
+	if !ok {
+		return nil, "", errors.New("cache not found")
+	}
+
+	key, err := url.PathUnescape(chi.URLParam(r, "key"))
+	if err != nil {
+		return nil, "", errors.New("invalid key")
+	}
+
+	return inspector, normalizeKey(name, key), nil
+}
+
+// normalizeKey puts a key in the form the use cases cache it under, so a zipcode can be looked
+// up or purged with or without its hyphen, and a city with its accents and capitals.
+func normalizeKey(cacheName string, key string) string {
+	switch cacheName {
+	case LocationsCache:
+		return location.CacheKey(key)
+	case ClimatesCache, GeocodingCache:
+		return climate.NormalizeCacheKey(key)
+	default:
+		return key
+	}
+}
+
+func dedupe(values []string) []string {
+	seen := make(map[string]bool, len(values))
+	unique := make([]string, 0, len(values))
+
+	for _, value := range values {
+		value = strings.TrimSpace(value)
+		if !seen[value] {
+			seen[value] = true
+			unique = append(unique, value)
+		}
+	}
+
+	return unique
+}
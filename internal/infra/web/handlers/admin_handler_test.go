@@ -0,0 +1,232 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"go.opentelemetry.io/otel"
+
+	"github.com/wellalencarweb/otel-lab-challenge/internal/entities"
+	"github.com/wellalencarweb/otel-lab-challenge/internal/pkg/cache"
+	"github.com/wellalencarweb/otel-lab-challenge/internal/pkg/customerrors"
+	"github.com/wellalencarweb/otel-lab-challenge/internal/pkg/mocks"
+	"github.com/wellalencarweb/otel-lab-challenge/internal/pkg/responsehandler"
+)
+
+const adminToken = "s3cr3t"
+
+type AdminHandlerTestSuite struct {
+	suite.Suite
+	FindLocationByZipCodeUseCaseMock *mocks.FindByZipCodeUseCaseMock
+	FindClimateByCityNameUseCaseMock *mocks.FindByCityNameUseCaseMock
+	Locations                        *cache.LRU[string]
+	Climates                         *cache.LRU[string]
+	Router                           chi.Router
+}
+
+func TestAdminHandler(t *testing.T) {
+	suite.Run(t, new(AdminHandlerTestSuite))
+}
+
+func (s *AdminHandlerTestSuite) SetupTest() {
+	s.FindLocationByZipCodeUseCaseMock = new(mocks.FindByZipCodeUseCaseMock)
+	s.FindClimateByCityNameUseCaseMock = new(mocks.FindByCityNameUseCaseMock)
+	s.Locations = cache.NewLRU[string](10)
+	s.Climates = cache.NewLRU[string](10)
+
+	handler := NewWebAdminHandler(
+		responsehandler.NewWebResponseHandler(),
+		adminToken,
+		map[string]cache.Inspector{
+			LocationsCache: cache.NewInspector[string](s.Locations),
+			ClimatesCache:  cache.NewInspector[string](s.Climates),
+		},
+		s.FindLocationByZipCodeUseCaseMock,
+		s.FindClimateByCityNameUseCaseMock,
+		2,
+		4,
+		otel.Tracer("admin-test"),
+	)
+
+	// mirrors AdminWebRouter, which lives in the web package
+	s.Router = chi.NewRouter()
+	s.Router.Get("/admin/caches", handler.Authorize(handler.GetCacheStats))
+	s.Router.Get("/admin/caches/{cache}/keys/{key}", handler.Authorize(handler.GetCacheEntry))
+	s.Router.Delete("/admin/caches/{cache}/keys/{key}", handler.Authorize(handler.PurgeCacheEntry))
+	s.Router.Delete("/admin/caches/{cache}/keys", handler.Authorize(handler.PurgeCacheEntries))
+	s.Router.Post("/admin/caches/warmup", handler.Authorize(handler.WarmUpCaches))
+}
+
+func (s *AdminHandlerTestSuite) do(method string, target string, body string, token string) (int, string) {
+	req := httptest.NewRequest(method, target, strings.NewReader(body))
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	w := httptest.NewRecorder()
+
+	s.Router.ServeHTTP(w, req)
+
+	res := w.Result()
+	defer res.Body.Close()
+
+	data, _ := io.ReadAll(res.Body)
+
+	return res.StatusCode, strings.TrimSuffix(string(data), "\n")
+}
+
+func (s *AdminHandlerTestSuite) TestAuthorize() {
+	s.Run("should reject requests without the admin token", func() {
+		status, body := s.do(http.MethodGet, "/admin/caches", "", "")
+		s.Equal(http.StatusUnauthorized, status)
+		s.Equal("{\"message\":\"invalid admin token\"}", body)
+	})
+
+	s.Run("should reject requests with a wrong admin token", func() {
+		status, _ := s.do(http.MethodGet, "/admin/caches", "", "wrong")
+		s.Equal(http.StatusUnauthorized, status)
+	})
+
+	s.Run("should accept the token in the X-Admin-Token header", func() {
+		req := httptest.NewRequest(http.MethodGet, "/admin/caches", nil)
+		req.Header.Set(AdminTokenHeader, adminToken)
+		w := httptest.NewRecorder()
+
+		s.Router.ServeHTTP(w, req)
+
+		s.Equal(http.StatusOK, w.Code)
+	})
+}
+
+func (s *AdminHandlerTestSuite) TestGetCacheStats() {
+	s.Locations.Set("22021001", "Rio de Janeiro", time.Minute)
+	s.Locations.Get("22021001")
+	s.Locations.Get("01001000")
+
+	status, body := s.do(http.MethodGet, "/admin/caches", "", adminToken)
+	s.Equal(http.StatusOK, status)
+	s.Equal("{\"climates\":{\"entries\":0,\"hits\":0,\"misses\":0,\"evictions\":0,\"hit_ratio\":0},\"locations\":{\"entries\":1,\"hits\":1,\"misses\":1,\"evictions\":0,\"hit_ratio\":0.5}}", body)
+}
+
+func (s *AdminHandlerTestSuite) TestGetCacheEntry() {
+	s.Locations.Set("rio de janeiro|rj", "Rio de Janeiro", time.Minute)
+
+	s.Run("should return an escaped key with its value", func() {
+		status, body := s.do(http.MethodGet, "/admin/caches/locations/keys/"+url.PathEscape("rio de janeiro|rj"), "", adminToken)
+		s.Equal(http.StatusOK, status)
+		s.Contains(body, "\"key\":\"rio de janeiro|rj\",\"value\":\"Rio de Janeiro\"")
+	})
+
+	s.Run("should look zipcodes up with or without the hyphen", func() {
+		s.Locations.Set("22021001", "Rio de Janeiro", time.Minute)
+
+		status, body := s.do(http.MethodGet, "/admin/caches/locations/keys/22021-001", "", adminToken)
+		s.Equal(http.StatusOK, status)
+		s.Contains(body, "\"key\":\"22021001\",\"value\":\"Rio de Janeiro\"")
+	})
+
+	s.Run("should look cities up with their accents and capitals", func() {
+		s.Climates.Set("sao paulo|sp", "23", time.Minute)
+
+		status, body := s.do(http.MethodGet, "/admin/caches/climates/keys/"+url.PathEscape("São Paulo|SP"), "", adminToken)
+		s.Equal(http.StatusOK, status)
+		s.Contains(body, "\"key\":\"sao paulo|sp\",\"value\":\"23\"")
+	})
+
+	s.Run("should return not found for unknown keys and caches", func() {
+		status, _ := s.do(http.MethodGet, "/admin/caches/locations/keys/01001000", "", adminToken)
+		s.Equal(http.StatusNotFound, status)
+
+		status, body := s.do(http.MethodGet, "/admin/caches/forecasts/keys/01001000", "", adminToken)
+		s.Equal(http.StatusNotFound, status)
+		s.Equal("{\"message\":\"cache not found\"}", body)
+	})
+}
+
+func (s *AdminHandlerTestSuite) TestPurge() {
+	s.Locations.Set("22021001", "Rio de Janeiro", time.Minute)
+	s.Locations.Set("22041001", "Rio de Janeiro", time.Minute)
+	s.Locations.Set("01001000", "São Paulo", time.Minute)
+
+	s.Run("should purge a single key, with or without the zipcode hyphen", func() {
+		status, body := s.do(http.MethodDelete, "/admin/caches/locations/keys/01001-000", "", adminToken)
+		s.Equal(http.StatusOK, status)
+		s.Equal("{\"purged\":1}", body)
+
+		status, _ = s.do(http.MethodDelete, "/admin/caches/locations/keys/01001000", "", adminToken)
+		s.Equal(http.StatusNotFound, status)
+	})
+
+	s.Run("should require a prefix to purge many keys", func() {
+		status, _ := s.do(http.MethodDelete, "/admin/caches/locations/keys", "", adminToken)
+		s.Equal(http.StatusBadRequest, status)
+	})
+
+	s.Run("should purge keys by prefix", func() {
+		status, body := s.do(http.MethodDelete, "/admin/caches/locations/keys?prefix=220", "", adminToken)
+		s.Equal(http.StatusOK, status)
+		s.Equal("{\"purged\":2}", body)
+		s.Equal(0, s.Locations.Len())
+	})
+
+	s.Run("should purge cities by an accented prefix", func() {
+		s.Climates.Set("sao paulo|sp", "23", time.Minute)
+		s.Climates.Set("sao jose|sc", "20", time.Minute)
+		s.Climates.Set("santos|sp", "25", time.Minute)
+
+		status, body := s.do(http.MethodDelete, "/admin/caches/climates/keys?prefix="+url.QueryEscape("São"), "", adminToken)
+		s.Equal(http.StatusOK, status)
+		s.Equal("{\"purged\":2}", body)
+		s.Equal(1, s.Climates.Len())
+	})
+}
+
+func (s *AdminHandlerTestSuite) TestWarmUpCaches() {
+	s.FindLocationByZipCodeUseCaseMock.On("Execute", mock.Anything, "22021001").Return(&entities.Location{City: "Rio de Janeiro", State: "RJ"}, nil).Once()
+	s.FindLocationByZipCodeUseCaseMock.On("Execute", mock.Anything, "99999999").Return((*entities.Location)(nil), &customerrors.NotFoundError{
+		Err:     errors.New("zipcode not found"),
+		Message: "can not find zipcode",
+	}).Once()
+	s.FindClimateByCityNameUseCaseMock.On("Execute", mock.Anything, "Rio de Janeiro", "RJ").Return(&entities.Climate{}, nil).Once()
+
+	status, body := s.do(http.MethodPost, "/admin/caches/warmup", "{\"zipcodes\":[\"22021001\",\"22021001\",\"99999999\",\"123\"]}", adminToken)
+	s.Equal(http.StatusOK, status)
+	s.Contains(body, "\"requested\":3,\"warmed\":1,\"failed\":2")
+	s.Contains(body, "{\"zipcode\":\"99999999\",\"message\":\"can not find zipcode\"}")
+	s.Contains(body, "{\"zipcode\":\"123\",\"message\":\"invalid zipcode\"}")
+
+	s.FindLocationByZipCodeUseCaseMock.AssertExpectations(s.T())
+	s.FindClimateByCityNameUseCaseMock.AssertExpectations(s.T())
+}
+
+func (s *AdminHandlerTestSuite) TestWarmUpCachesLimits() {
+	s.Run("should reject more zipcodes than a batch takes", func() {
+		status, body := s.do(http.MethodPost, "/admin/caches/warmup", "{\"zipcodes\":[\"1\",\"2\",\"3\",\"4\",\"5\"]}", adminToken)
+		s.Equal(http.StatusBadRequest, status)
+		s.Equal("{\"message\":\"invalid body, must have at most 4 zipcodes\"}", body)
+	})
+
+	s.Run("should not start lookups once the client is gone", func() {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		req := httptest.NewRequest(http.MethodPost, "/admin/caches/warmup", strings.NewReader("{\"zipcodes\":[\"22021001\",\"01001000\",\"69900062\"]}")).WithContext(ctx)
+		req.Header.Set(AdminTokenHeader, adminToken)
+		w := httptest.NewRecorder()
+
+		s.Router.ServeHTTP(w, req)
+
+		s.Equal(http.StatusOK, w.Code)
+		s.Contains(w.Body.String(), "\"requested\":3,\"warmed\":0,\"failed\":0")
+		s.FindLocationByZipCodeUseCaseMock.AssertNotCalled(s.T(), "Execute", mock.Anything, mock.Anything)
+	})
+}
@@ -0,0 +1,108 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/wellalencarweb/otel-lab-challenge/internal/entities/dto"
+	"github.com/wellalencarweb/otel-lab-challenge/internal/pkg/responsehandler"
+	"github.com/wellalencarweb/otel-lab-challenge/internal/usecases/bulk"
+)
+
+const DefaultBatchMaxSize = 100
+
+type WebBatchHandlerInterface interface {
+	GetTemperaturesByZipCodes(w http.ResponseWriter, r *http.Request)
+}
+
+type WebBatchHandler struct {
+	ResponseHandler        responsehandler.WebResponseHandlerInterface
+	ResolveZipCodesUseCase bulk.ResolveZipCodesUseCaseInterface
+	MaxSize                int
+	Tracer                 trace.Tracer
+}
+
+func NewWebBatchHandler(
+	rh responsehandler.WebResponseHandlerInterface,
+	resolveZipCodesUC bulk.ResolveZipCodesUseCaseInterface,
+	maxSize int,
+	tracer trace.Tracer,
+) *WebBatchHandler {
+	if maxSize <= 0 {
+		maxSize = DefaultBatchMaxSize
+	}
+
+	return &WebBatchHandler{
+		ResponseHandler:        rh,
+		ResolveZipCodesUseCase: resolveZipCodesUC,
+		MaxSize:                maxSize,
+		Tracer:                 tracer,
+	}
+}
+
+// GetTemperaturesByZipCodes resolves up to MaxSize zipcodes in one request, each under its own
+// span below the batch span. It answers 200 even when some of them fail.
+func (h *WebBatchHandler) GetTemperaturesByZipCodes(w http.ResponseWriter, r *http.Request) {
+	carrier := propagation.HeaderCarrier(r.Header)
+	ctx := otel.GetTextMapPropagator().Extract(r.Context(), carrier)
+	ctx, span := h.Tracer.Start(ctx, "batch")
+	defer span.End()
+
+	var input dto.BatchInput
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		span.SetStatus(codes.Error, "invalid body")
+		span.RecordError(err)
+
+		h.ResponseHandler.RespondWithError(w, http.StatusBadRequest, errors.New("invalid body"))
+		return
+	}
+
+	if err := input.Validate(h.MaxSize); err != nil {
+		span.SetStatus(codes.Error, "invalid batch")
+		span.RecordError(err)
+
+		h.ResponseHandler.RespondWithError(w, http.StatusUnprocessableEntity, err)
+		return
+	}
+
+	span.SetAttributes(attribute.Int("batch.size", len(input.Zipcodes)))
+
+	zipCodes := make(chan string, len(input.Zipcodes))
+	for _, zipCode := range input.Zipcodes {
+		zipCodes <- zipCode
+	}
+	close(zipCodes)
+
+	results := make(map[string]dto.BulkResolveResult, len(input.Zipcodes))
+	summary := h.ResolveZipCodesUseCase.Resolve(ctx, zipCodes, func(result dto.BulkResolveResult) {
+		results[result.Zipcode] = result
+	})
+
+	output := dto.BatchOutput{
+		Total:      summary.Total,
+		Resolved:   summary.Resolved,
+		Failed:     summary.Failed,
+		Duplicates: summary.Duplicates,
+		DurationMs: summary.Duration.Milliseconds(),
+		Results:    make([]dto.BulkResolveResult, 0, len(results)),
+	}
+
+	for _, zipCode := range input.Zipcodes {
+		result, ok := results[bulk.NormalizeZipCode(zipCode)]
+		if !ok {
+			continue
+		}
+
+		output.Results = append(output.Results, result)
+		delete(results, result.Zipcode)
+	}
+
+	h.ResponseHandler.Respond(w, http.StatusOK, output)
+}
@@ -0,0 +1,121 @@
+package handlers
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+
+	"github.com/wellalencarweb/otel-lab-challenge/internal/entities"
+	"github.com/wellalencarweb/otel-lab-challenge/internal/pkg/customerrors"
+	"github.com/wellalencarweb/otel-lab-challenge/internal/pkg/mocks"
+	"github.com/wellalencarweb/otel-lab-challenge/internal/pkg/responsehandler"
+	"github.com/wellalencarweb/otel-lab-challenge/internal/usecases/bulk"
+)
+
+type BatchHandlerTestSuite struct {
+	suite.Suite
+	FindLocationByZipCodeUseCaseMock *mocks.FindByZipCodeUseCaseMock
+	FindClimateByCityNameUseCaseMock *mocks.FindByCityNameUseCaseMock
+	Recorder                         *tracetest.SpanRecorder
+	WebBatchHandler                  *WebBatchHandler
+}
+
+func TestBatchHandler(t *testing.T) {
+	suite.Run(t, new(BatchHandlerTestSuite))
+}
+
+func (s *BatchHandlerTestSuite) SetupTest() {
+	s.FindLocationByZipCodeUseCaseMock = new(mocks.FindByZipCodeUseCaseMock)
+	s.FindClimateByCityNameUseCaseMock = new(mocks.FindByCityNameUseCaseMock)
+	s.Recorder = tracetest.NewSpanRecorder()
+
+	tracer := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(s.Recorder)).Tracer("batch-test")
+
+	resolveZipCodesUC := bulk.NewResolveZipCodesUseCase(
+		s.FindLocationByZipCodeUseCaseMock,
+		s.FindClimateByCityNameUseCaseMock,
+		nil,
+		bulk.Settings{Concurrency: 2},
+		zerolog.Nop(),
+		tracer,
+	)
+
+	s.WebBatchHandler = NewWebBatchHandler(responsehandler.NewWebResponseHandler(), resolveZipCodesUC, 3, tracer)
+}
+
+func (s *BatchHandlerTestSuite) post(body string) (int, string) {
+	req := httptest.NewRequest(http.MethodPost, "/batch", strings.NewReader(body))
+	w := httptest.NewRecorder()
+
+	s.WebBatchHandler.GetTemperaturesByZipCodes(w, req)
+
+	res := w.Result()
+	defer res.Body.Close()
+
+	data, _ := io.ReadAll(res.Body)
+
+	return res.StatusCode, strings.TrimSuffix(string(data), "\n")
+}
+
+func (s *BatchHandlerTestSuite) TestGetTemperaturesByZipCodes() {
+	s.Run("should resolve distinct zipcodes in request order with per zipcode errors", func() {
+		s.FindLocationByZipCodeUseCaseMock.On("Execute", mock.Anything, "22021001").Return(&entities.Location{City: "Rio de Janeiro", State: "RJ"}, nil).Once()
+		s.FindLocationByZipCodeUseCaseMock.On("Execute", mock.Anything, "99999999").Return((*entities.Location)(nil), &customerrors.NotFoundError{
+			Err:     errors.New("zipcode not found"),
+			Message: "can not find zipcode",
+		}).Once()
+		s.FindClimateByCityNameUseCaseMock.On("Execute", mock.Anything, "Rio de Janeiro", "RJ").Return(&entities.Climate{
+			Current: entities.ClimateData{TempC: 30},
+		}, nil).Once()
+
+		status, body := s.post("{\"ceps\":[\"99999999\",\"22021-001\",\"22021001\"]}")
+
+		s.Equal(http.StatusOK, status)
+		s.Contains(body, "\"total\":3,\"resolved\":1,\"failed\":1,\"duplicates\":1,")
+		s.Contains(body, "\"results\":[{\"zipcode\":\"99999999\",\"error\":\"can not find zipcode\",")
+		s.Contains(body, "{\"zipcode\":\"22021001\",\"city\":\"Rio de Janeiro\",\"temp_C\":30,\"temp_F\":86,\"temp_K\":303.15,\"state\":\"RJ\",")
+		s.FindLocationByZipCodeUseCaseMock.AssertExpectations(s.T())
+
+		spans := s.Recorder.Ended()
+		root := spans[len(spans)-1]
+		s.Equal("batch", root.Name())
+
+		var children []string
+		for _, span := range spans {
+			if span.Parent().SpanID() == root.SpanContext().SpanID() {
+				children = append(children, span.Name())
+			}
+		}
+		s.Equal([]string{"resolve-zipcode", "resolve-zipcode"}, children)
+	})
+
+	s.Run("should reject batches over the limit", func() {
+		status, body := s.post("{\"ceps\":[\"01001000\",\"22021001\",\"69900000\",\"29902555\"]}")
+
+		s.Equal(http.StatusUnprocessableEntity, status)
+		s.Equal("{\"message\":\"invalid batch, must have between 1 and 3 ceps\"}", body)
+	})
+
+	s.Run("should reject empty batches", func() {
+		status, body := s.post("{\"ceps\":[]}")
+
+		s.Equal(http.StatusUnprocessableEntity, status)
+		s.Equal("{\"message\":\"invalid batch, must have between 1 and 3 ceps\"}", body)
+	})
+
+	s.Run("should reject invalid bodies", func() {
+		status, body := s.post("[\"01001000\"]")
+
+		s.Equal(http.StatusBadRequest, status)
+		s.Equal("{\"message\":\"invalid body\"}", body)
+	})
+}
@@ -2,15 +2,20 @@ package handlers
 
 import (
 	"errors"
+	"math"
 	"net/http"
 	"regexp"
+	"strconv"
 
 	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
 	"go.opentelemetry.io/otel/codes"
 	"go.opentelemetry.io/otel/propagation"
 	"go.opentelemetry.io/otel/trace"
 
+	"github.com/wellalencarweb/otel-lab-challenge/internal/entities"
 	"github.com/wellalencarweb/otel-lab-challenge/internal/entities/dto"
+	"github.com/wellalencarweb/otel-lab-challenge/internal/pkg/customerrors"
 	"github.com/wellalencarweb/otel-lab-challenge/internal/pkg/responsehandler"
 	"github.com/wellalencarweb/otel-lab-challenge/internal/usecases/climate"
 	"github.com/wellalencarweb/otel-lab-challenge/internal/usecases/location"
@@ -59,6 +64,19 @@ func (h *WebClimateHandler) GetTemperaturesByZipCode(w http.ResponseWriter, r *h
 		return
 	}
 
+	fields, err := dto.ParseTemperatureFields(qs.Get("fields"), qs.Get("expand"))
+	if err != nil {
+		span.SetStatus(codes.Error, "invalid fields")
+		span.RecordError(err)
+
+		h.ResponseHandler.RespondWithError(w, http.StatusUnprocessableEntity, err)
+		return
+	}
+
+	if len(fields) > 0 {
+		span.SetAttributes(attribute.StringSlice("response.fields", fields))
+	}
+
 	zipCodeCtx, zipCodeSpan := h.Tracer.Start(ctx, "find-location-by-zipcode")
 	location, err := h.FindLocationByZipCodeUseCase.Execute(zipCodeCtx, zipStr)
 	if err != nil {
@@ -66,7 +84,8 @@ func (h *WebClimateHandler) GetTemperaturesByZipCode(w http.ResponseWriter, r *h
 		zipCodeSpan.RecordError(err)
 		zipCodeSpan.End()
 
-		h.ResponseHandler.RespondWithError(w, http.StatusInternalServerError, err)
+		writeRetryAfter(w, err)
+		h.ResponseHandler.RespondWithError(w, errorStatusCode(err), err)
 		return
 	}
 	if location.City == "" {
@@ -81,26 +100,86 @@ func (h *WebClimateHandler) GetTemperaturesByZipCode(w http.ResponseWriter, r *h
 	zipCodeSpan.End()
 
 	climateCtx, climateSpan := h.Tracer.Start(ctx, "find-climate-by-city-name")
-	climate, err := h.FindClimateByCityNameUseCase.Execute(climateCtx, location.City)
+	climate, err := h.FindClimateByCityNameUseCase.Execute(climateCtx, location.City, location.State)
 	if err != nil {
 		climateSpan.SetStatus(codes.Error, "error finding climate by city name")
 		climateSpan.RecordError(err)
 		climateSpan.End()
 
-		h.ResponseHandler.RespondWithError(w, http.StatusInternalServerError, err)
+		writeRetryAfter(w, err)
+		h.ResponseHandler.RespondWithError(w, errorStatusCode(err), err)
 		return
 	}
 
 	climateSpan.End()
 
-	fahrenheit, kelvin := convertTemperature(climate.Current.TempC)
+	h.ResponseHandler.Respond(w, http.StatusOK, temperaturesOutput(location, climate, fields))
+}
+
+// temperaturesOutput also fills the optional fields the client asked for from the current
+// conditions and the address the zipcode resolved to.
+func temperaturesOutput(location *entities.Location, climate *entities.Climate, fields []string) dto.GetTemperaturesByZipCodeOutput {
+	current := climate.Current
+	fahrenheit, kelvin := convertTemperature(current.TempC)
 
-	h.ResponseHandler.Respond(w, http.StatusOK, dto.GetTemperaturesByZipCodeOutput{
+	output := dto.GetTemperaturesByZipCodeOutput{
 		City:       location.City,
-		Celcius:    float32(climate.Current.TempC),
+		Celcius:    float32(current.TempC),
 		Fahrenheit: float32(fahrenheit),
 		Kelvin:     float32(kelvin),
-	})
+		Latitude:   climate.Location.Latitude,
+		Longitude:  climate.Location.Longitude,
+		Stale:      climate.Stale,
+		Consensus:  dto.NewTemperatureConsensusOutput(climate.Consensus),
+	}
+
+	for _, field := range fields {
+		switch field {
+		case dto.FieldFeelsLike:
+			feelsLike := temperatureOutput(current.FeelsLikeC)
+			output.FeelsLike = &feelsLike
+		case dto.FieldHumidity:
+			output.Humidity = &current.Humidity
+		case dto.FieldWind:
+			output.Wind = &dto.WindOutput{SpeedKph: float32(current.WindKph), Degree: current.WindDegree}
+		case dto.FieldPressure:
+			pressure := float32(current.PressureMb)
+			output.PressureMb = &pressure
+		case dto.FieldUv:
+			uv := float32(current.Uv)
+			output.Uv = &uv
+		case dto.FieldCondition:
+			output.Condition = current.Condition
+		case dto.FieldAddress:
+			output.Address = dto.NewAddressOutput(location)
+		}
+	}
+
+	return output
+}
+
+func errorStatusCode(err error) int {
+	var notFoundErr *customerrors.NotFoundError
+	var unavailableErr *customerrors.ServiceUnavailableError
+	var tooManyRequestsErr *customerrors.TooManyRequestsError
+
+	switch {
+	case errors.As(err, &notFoundErr):
+		return http.StatusNotFound
+	case errors.As(err, &tooManyRequestsErr):
+		return http.StatusTooManyRequests
+	case errors.As(err, &unavailableErr):
+		return http.StatusServiceUnavailable
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+func writeRetryAfter(w http.ResponseWriter, err error) {
+	var tooManyRequestsErr *customerrors.TooManyRequestsError
+	if errors.As(err, &tooManyRequestsErr) && tooManyRequestsErr.RetryAfter > 0 {
+		w.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(tooManyRequestsErr.RetryAfter.Seconds()))))
+	}
 }
 
 func validateInput(zipcode string) error {
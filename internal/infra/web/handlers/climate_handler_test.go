@@ -7,9 +7,12 @@ import (
 	"net/http/httptest"
 	"strings"
 	"testing"
+	"time"
 
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/suite"
 	"github.com/wellalencarweb/otel-lab-challenge/internal/entities"
+	"github.com/wellalencarweb/otel-lab-challenge/internal/pkg/customerrors"
 	"github.com/wellalencarweb/otel-lab-challenge/internal/pkg/mocks"
 	"github.com/wellalencarweb/otel-lab-challenge/internal/pkg/responsehandler"
 	"go.opentelemetry.io/otel"
@@ -71,8 +74,8 @@ func (s *ClimateHandlerTestSuite) TestGetTemperaturesByZipCode() {
 			},
 		}
 
-		s.FindLocationByZipCodeUseCaseMock.On("Execute", zipCode).Return(&expectedLocation, nil)
-		s.FindClimateByCityNameUseCaseMock.On("Execute", city).Return(&expectedClimate, nil)
+		s.FindLocationByZipCodeUseCaseMock.On("Execute", mock.Anything, zipCode).Return(&expectedLocation, nil)
+		s.FindClimateByCityNameUseCaseMock.On("Execute", mock.Anything, city, "").Return(&expectedClimate, nil)
 
 		s.WebClimateHandler.GetTemperaturesByZipCode(w, req)
 
@@ -80,7 +83,7 @@ func (s *ClimateHandlerTestSuite) TestGetTemperaturesByZipCode() {
 		defer res.Body.Close()
 
 		data, _ := io.ReadAll(res.Body)
-		expectedResponse := "{\"temp_C\":30,\"temp_F\":86,\"temp_K\":303.15}"
+		expectedResponse := "{\"city\":\"Rio de Janeiro\",\"temp_C\":30,\"temp_F\":86,\"temp_K\":303.15}"
 
 		s.Equal(http.StatusOK, res.StatusCode)
 		s.Equal(expectedResponse, strings.TrimSuffix(string(data), "\n"))
@@ -136,7 +139,7 @@ func (s *ClimateHandlerTestSuite) TestGetTemperaturesByZipCode() {
 			Zipcode: zipCode,
 		}
 
-		s.FindLocationByZipCodeUseCaseMock.On("Execute", zipCode).Return(&expectedLocation, nil)
+		s.FindLocationByZipCodeUseCaseMock.On("Execute", mock.Anything, zipCode).Return(&expectedLocation, nil)
 
 		s.WebClimateHandler.GetTemperaturesByZipCode(w, req)
 
@@ -149,4 +152,238 @@ func (s *ClimateHandlerTestSuite) TestGetTemperaturesByZipCode() {
 		s.Equal(http.StatusNotFound, res.StatusCode)
 		s.Equal(expectedResponse, strings.TrimSuffix(string(data), "\n"))
 	})
+	s.Run("should return service unavailable when upstream circuit is open", func() {
+		defer s.clearMocks()
+
+		zipCode := "22021001"
+
+		req := httptest.NewRequest(http.MethodGet, fmt.Sprintf("/?zipcode=%s", zipCode), nil)
+		w := httptest.NewRecorder()
+
+		s.FindLocationByZipCodeUseCaseMock.On("Execute", mock.Anything, zipCode).Return((*entities.Location)(nil), &customerrors.ServiceUnavailableError{
+			Message: "viacep is unavailable",
+		})
+
+		s.WebClimateHandler.GetTemperaturesByZipCode(w, req)
+
+		res := w.Result()
+		defer res.Body.Close()
+
+		data, _ := io.ReadAll(res.Body)
+		expectedResponse := "{\"message\":\"viacep is unavailable\"}"
+
+		s.Equal(http.StatusServiceUnavailable, res.StatusCode)
+		s.Equal(expectedResponse, strings.TrimSuffix(string(data), "\n"))
+	})
+	s.Run("should return too many requests with retry after when rate limited", func() {
+		defer s.clearMocks()
+
+		zipCode := "22021001"
+		city := "Rio de Janeiro"
+
+		req := httptest.NewRequest(http.MethodGet, fmt.Sprintf("/?zipcode=%s", zipCode), nil)
+		w := httptest.NewRecorder()
+
+		expectedLocation := entities.Location{
+			City:    city,
+			Zipcode: zipCode,
+		}
+
+		s.FindLocationByZipCodeUseCaseMock.On("Execute", mock.Anything, zipCode).Return(&expectedLocation, nil)
+		s.FindClimateByCityNameUseCaseMock.On("Execute", mock.Anything, city, "").Return((*entities.Climate)(nil), &customerrors.TooManyRequestsError{
+			Message:    "weatherapi rate limit exceeded",
+			RetryAfter: 1500 * time.Millisecond,
+		})
+
+		s.WebClimateHandler.GetTemperaturesByZipCode(w, req)
+
+		res := w.Result()
+		defer res.Body.Close()
+
+		data, _ := io.ReadAll(res.Body)
+		expectedResponse := "{\"message\":\"weatherapi rate limit exceeded\"}"
+
+		s.Equal(http.StatusTooManyRequests, res.StatusCode)
+		s.Equal("2", res.Header.Get("Retry-After"))
+		s.Equal(expectedResponse, strings.TrimSuffix(string(data), "\n"))
+	})
+	s.Run("should flag stale temperatures", func() {
+		defer s.clearMocks()
+
+		zipCode := "22021001"
+		city := "Rio de Janeiro"
+
+		req := httptest.NewRequest(http.MethodGet, fmt.Sprintf("/?zipcode=%s", zipCode), nil)
+		w := httptest.NewRecorder()
+
+		expectedLocation := entities.Location{
+			City:    city,
+			State:   "RJ",
+			Zipcode: zipCode,
+		}
+
+		expectedClimate := entities.Climate{
+			Current: entities.ClimateData{
+				TempC: 30,
+			},
+			Stale: true,
+		}
+
+		s.FindLocationByZipCodeUseCaseMock.On("Execute", mock.Anything, zipCode).Return(&expectedLocation, nil)
+		s.FindClimateByCityNameUseCaseMock.On("Execute", mock.Anything, city, "RJ").Return(&expectedClimate, nil)
+
+		s.WebClimateHandler.GetTemperaturesByZipCode(w, req)
+
+		res := w.Result()
+		defer res.Body.Close()
+
+		data, _ := io.ReadAll(res.Body)
+		expectedResponse := "{\"city\":\"Rio de Janeiro\",\"temp_C\":30,\"temp_F\":86,\"temp_K\":303.15,\"stale\":true}"
+
+		s.Equal(http.StatusOK, res.StatusCode)
+		s.Equal(expectedResponse, strings.TrimSuffix(string(data), "\n"))
+	})
+	s.Run("should detail consensus readings", func() {
+		defer s.clearMocks()
+
+		zipCode := "22021001"
+		city := "Rio de Janeiro"
+
+		req := httptest.NewRequest(http.MethodGet, fmt.Sprintf("/?zipcode=%s", zipCode), nil)
+		w := httptest.NewRecorder()
+
+		expectedLocation := entities.Location{
+			City:    city,
+			State:   "RJ",
+			Zipcode: zipCode,
+		}
+
+		expectedClimate := entities.Climate{
+			Provider: "consensus",
+			Current: entities.ClimateData{
+				TempC: 30,
+			},
+			Consensus: &entities.ClimateConsensus{
+				Readings: []entities.ClimateReading{
+					{Provider: "weatherapi", TempC: 30},
+					{Provider: "openmeteo", TempC: 0, Outlier: true},
+					{Provider: "metno", Error: "unavailable"},
+				},
+			},
+		}
+
+		s.FindLocationByZipCodeUseCaseMock.On("Execute", mock.Anything, zipCode).Return(&expectedLocation, nil)
+		s.FindClimateByCityNameUseCaseMock.On("Execute", mock.Anything, city, "RJ").Return(&expectedClimate, nil)
+
+		s.WebClimateHandler.GetTemperaturesByZipCode(w, req)
+
+		res := w.Result()
+		defer res.Body.Close()
+
+		data, _ := io.ReadAll(res.Body)
+		expectedResponse := "{\"city\":\"Rio de Janeiro\",\"temp_C\":30,\"temp_F\":86,\"temp_K\":303.15," +
+			"\"consensus\":{\"spread_C\":0,\"readings\":[{\"provider\":\"weatherapi\",\"temp_C\":30}," +
+			"{\"provider\":\"openmeteo\",\"temp_C\":0,\"outlier\":true},{\"provider\":\"metno\",\"error\":\"unavailable\"}]}}"
+
+		s.Equal(http.StatusOK, res.StatusCode)
+		s.Equal(expectedResponse, strings.TrimSuffix(string(data), "\n"))
+	})
+	s.Run("should answer the requested extra fields", func() {
+		defer s.clearMocks()
+
+		zipCode := "22021001"
+		city := "Rio de Janeiro"
+
+		req := httptest.NewRequest(http.MethodGet, fmt.Sprintf("/?zipcode=%s&fields=humidity,wind&expand=uv,%%20address,humidity", zipCode), nil)
+		w := httptest.NewRecorder()
+
+		expectedLocation := entities.Location{
+			Zipcode:      zipCode,
+			AddressLine1: "Avenida Atlântica",
+			Neighborhood: "Copacabana",
+			City:         city,
+			State:        "RJ",
+		}
+
+		expectedClimate := entities.Climate{
+			Current: entities.ClimateData{
+				TempC:      30,
+				FeelsLikeC: 33,
+				Humidity:   0,
+				WindKph:    12.5,
+				WindDegree: 90,
+				PressureMb: 1012,
+				Uv:         7,
+				Condition:  "Sunny",
+			},
+		}
+
+		s.FindLocationByZipCodeUseCaseMock.On("Execute", mock.Anything, zipCode).Return(&expectedLocation, nil)
+		s.FindClimateByCityNameUseCaseMock.On("Execute", mock.Anything, city, "RJ").Return(&expectedClimate, nil)
+
+		s.WebClimateHandler.GetTemperaturesByZipCode(w, req)
+
+		res := w.Result()
+		defer res.Body.Close()
+
+		data, _ := io.ReadAll(res.Body)
+		expectedResponse := "{\"city\":\"Rio de Janeiro\",\"temp_C\":30,\"temp_F\":86,\"temp_K\":303.15," +
+			"\"humidity\":0,\"wind\":{\"speed_kph\":12.5,\"degree\":90},\"uv\":7," +
+			"\"address\":{\"zipcode\":\"22021001\",\"street\":\"Avenida Atlântica\",\"neighborhood\":\"Copacabana\",\"city\":\"Rio de Janeiro\",\"state\":\"RJ\"}}"
+
+		s.Equal(http.StatusOK, res.StatusCode)
+		s.Equal(expectedResponse, strings.TrimSuffix(string(data), "\n"))
+	})
+	s.Run("should answer every extra field", func() {
+		defer s.clearMocks()
+
+		zipCode := "22021001"
+		city := "Rio de Janeiro"
+
+		req := httptest.NewRequest(http.MethodGet, fmt.Sprintf("/?zipcode=%s&fields=feelslike,pressure,condition", zipCode), nil)
+		w := httptest.NewRecorder()
+
+		expectedLocation := entities.Location{City: city, State: "RJ", Zipcode: zipCode}
+
+		expectedClimate := entities.Climate{
+			Current: entities.ClimateData{
+				TempC:      30,
+				FeelsLikeC: 33,
+				PressureMb: 1012.5,
+				Condition:  "Sunny",
+			},
+		}
+
+		s.FindLocationByZipCodeUseCaseMock.On("Execute", mock.Anything, zipCode).Return(&expectedLocation, nil)
+		s.FindClimateByCityNameUseCaseMock.On("Execute", mock.Anything, city, "RJ").Return(&expectedClimate, nil)
+
+		s.WebClimateHandler.GetTemperaturesByZipCode(w, req)
+
+		res := w.Result()
+		defer res.Body.Close()
+
+		data, _ := io.ReadAll(res.Body)
+		expectedResponse := "{\"city\":\"Rio de Janeiro\",\"temp_C\":30,\"temp_F\":86,\"temp_K\":303.15," +
+			"\"feelslike\":{\"temp_C\":33,\"temp_F\":91.4,\"temp_K\":306.15},\"pressure_mb\":1012.5,\"condition\":\"Sunny\"}"
+
+		s.Equal(http.StatusOK, res.StatusCode)
+		s.Equal(expectedResponse, strings.TrimSuffix(string(data), "\n"))
+	})
+	s.Run("should return error when a field is unknown", func() {
+		defer s.clearMocks()
+
+		req := httptest.NewRequest(http.MethodGet, "/?zipcode=22021001&fields=humidity,dewpoint", nil)
+		w := httptest.NewRecorder()
+
+		s.WebClimateHandler.GetTemperaturesByZipCode(w, req)
+
+		res := w.Result()
+		defer res.Body.Close()
+
+		data, _ := io.ReadAll(res.Body)
+		expectedResponse := "{\"message\":\"invalid field [dewpoint], must be one of feelslike, humidity, wind, pressure, uv, condition, address\"}"
+
+		s.Equal(http.StatusUnprocessableEntity, res.StatusCode)
+		s.Equal(expectedResponse, strings.TrimSuffix(string(data), "\n"))
+	})
 }
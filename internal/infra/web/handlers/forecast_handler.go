@@ -0,0 +1,270 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/wellalencarweb/otel-lab-challenge/internal/entities"
+	"github.com/wellalencarweb/otel-lab-challenge/internal/entities/dto"
+	"github.com/wellalencarweb/otel-lab-challenge/internal/pkg/responsehandler"
+	"github.com/wellalencarweb/otel-lab-challenge/internal/usecases/climate"
+	"github.com/wellalencarweb/otel-lab-challenge/internal/usecases/location"
+)
+
+const (
+	defaultForecastDays     = 3
+	defaultForecastMaxDays  = 7
+	defaultForecastHours    = 24
+	defaultForecastMaxHours = 48
+)
+
+// ForecastSettings bounds the days and hours a forecast may span; zero values use the defaults.
+type ForecastSettings struct {
+	MaxDays  int
+	MaxHours int
+}
+
+type WebForecastHandlerInterface interface {
+	GetForecastByZipCode(w http.ResponseWriter, r *http.Request)
+}
+
+type WebForecastHandler struct {
+	ResponseHandler                     responsehandler.WebResponseHandlerInterface
+	FindLocationByZipCodeUseCase        location.FindByZipCodeUseCaseInterface
+	FindForecastByCityNameUseCase       climate.FindForecastByCityNameUseCaseInterface
+	FindHourlyForecastByCityNameUseCase climate.FindHourlyForecastByCityNameUseCaseInterface
+	Settings                            ForecastSettings
+	Tracer                              trace.Tracer
+}
+
+func NewWebForecastHandler(
+	rh responsehandler.WebResponseHandlerInterface,
+	findByZipCodeUC location.FindByZipCodeUseCaseInterface,
+	findForecastByCityNameUC climate.FindForecastByCityNameUseCaseInterface,
+	findHourlyForecastByCityNameUC climate.FindHourlyForecastByCityNameUseCaseInterface,
+	settings ForecastSettings,
+	tracer trace.Tracer,
+) *WebForecastHandler {
+	if settings.MaxDays <= 0 {
+		settings.MaxDays = defaultForecastMaxDays
+	}
+
+	if settings.MaxHours <= 0 {
+		settings.MaxHours = defaultForecastMaxHours
+	}
+
+	return &WebForecastHandler{
+		ResponseHandler:                     rh,
+		FindLocationByZipCodeUseCase:        findByZipCodeUC,
+		FindForecastByCityNameUseCase:       findForecastByCityNameUC,
+		FindHourlyForecastByCityNameUseCase: findHourlyForecastByCityNameUC,
+		Settings:                            settings,
+		Tracer:                              tracer,
+	}
+}
+
+// GetForecastByZipCode answers the daily forecast for the next days, or, with mode=hourly, the
+// hourly forecast for the next hours, timed in the location's time zone or, with timezone=utc,
+// in UTC.
+func (h *WebForecastHandler) GetForecastByZipCode(w http.ResponseWriter, r *http.Request) {
+	carrier := propagation.HeaderCarrier(r.Header)
+	ctx := otel.GetTextMapPropagator().Extract(r.Context(), carrier)
+	ctx, span := h.Tracer.Start(ctx, "forecast")
+	defer span.End()
+
+	qs := r.URL.Query()
+	zipStr := qs.Get("zipcode")
+
+	if err := validateInput(zipStr); err != nil {
+		span.SetStatus(codes.Error, "invalid zipcode")
+		span.RecordError(err)
+
+		h.ResponseHandler.RespondWithError(w, http.StatusUnprocessableEntity, err)
+		return
+	}
+
+	request, err := h.parseRequest(qs)
+	if err != nil {
+		span.SetStatus(codes.Error, "invalid forecast request")
+		span.RecordError(err)
+
+		h.ResponseHandler.RespondWithError(w, http.StatusUnprocessableEntity, err)
+		return
+	}
+
+	zipCodeCtx, zipCodeSpan := h.Tracer.Start(ctx, "find-location-by-zipcode")
+	location, err := h.FindLocationByZipCodeUseCase.Execute(zipCodeCtx, zipStr)
+	if err != nil {
+		zipCodeSpan.SetStatus(codes.Error, "error finding location by zipcode")
+		zipCodeSpan.RecordError(err)
+		zipCodeSpan.End()
+
+		writeRetryAfter(w, err)
+		h.ResponseHandler.RespondWithError(w, errorStatusCode(err), err)
+		return
+	}
+	if location.City == "" {
+		zipCodeSpan.SetStatus(codes.Error, "zipcode not found")
+		zipCodeSpan.End()
+
+		h.ResponseHandler.RespondWithError(w, http.StatusNotFound, errors.New("zipcode not found"))
+		return
+	}
+
+	zipCodeSpan.End()
+
+	if request.hourly {
+		h.respondHourly(ctx, w, location, request)
+		return
+	}
+
+	forecastCtx, forecastSpan := h.Tracer.Start(ctx, "find-forecast-by-city-name")
+	forecast, err := h.FindForecastByCityNameUseCase.Execute(forecastCtx, location.City, location.State, request.days)
+	if err != nil {
+		forecastSpan.SetStatus(codes.Error, "error finding forecast by city name")
+		forecastSpan.RecordError(err)
+		forecastSpan.End()
+
+		writeRetryAfter(w, err)
+		h.ResponseHandler.RespondWithError(w, errorStatusCode(err), err)
+		return
+	}
+
+	forecastSpan.End()
+
+	output := dto.GetForecastByZipCodeOutput{
+		City:      location.City,
+		Latitude:  forecast.Location.Latitude,
+		Longitude: forecast.Location.Longitude,
+		Days:      make([]dto.ForecastDayOutput, 0, len(forecast.Days)),
+	}
+
+	for _, day := range forecast.Days {
+		output.Days = append(output.Days, forecastDayOutput(day))
+	}
+
+	h.ResponseHandler.Respond(w, http.StatusOK, output)
+}
+
+func (h *WebForecastHandler) respondHourly(ctx context.Context, w http.ResponseWriter, location *entities.Location, request forecastRequest) {
+	forecastCtx, forecastSpan := h.Tracer.Start(ctx, "find-hourly-forecast-by-city-name")
+	forecast, err := h.FindHourlyForecastByCityNameUseCase.Execute(forecastCtx, location.City, location.State, request.hours)
+	if err != nil {
+		forecastSpan.SetStatus(codes.Error, "error finding hourly forecast by city name")
+		forecastSpan.RecordError(err)
+		forecastSpan.End()
+
+		writeRetryAfter(w, err)
+		h.ResponseHandler.RespondWithError(w, errorStatusCode(err), err)
+		return
+	}
+
+	forecastSpan.End()
+
+	output := dto.GetHourlyForecastByZipCodeOutput{
+		City:      location.City,
+		Latitude:  forecast.Location.Latitude,
+		Longitude: forecast.Location.Longitude,
+		TimeZone:  forecast.Location.TimeZone,
+		Hours:     make([]dto.ForecastHourOutput, 0, len(forecast.Hours)),
+	}
+
+	if request.utc {
+		output.TimeZone = "UTC"
+	}
+
+	for _, hour := range forecast.Hours {
+		at := hour.Time
+		if request.utc {
+			at = at.UTC()
+		}
+
+		output.Hours = append(output.Hours, dto.ForecastHourOutput{
+			Time:              at.Format(time.RFC3339),
+			TemperatureOutput: temperatureOutput(hour.TempC),
+			Condition:         hour.Condition,
+		})
+	}
+
+	h.ResponseHandler.Respond(w, http.StatusOK, output)
+}
+
+type forecastRequest struct {
+	hourly bool
+	days   int
+	hours  int
+	utc    bool
+}
+
+func (h *WebForecastHandler) parseRequest(qs url.Values) (forecastRequest, error) {
+	var request forecastRequest
+	var err error
+
+	switch qs.Get("mode") {
+	case "", "daily":
+		request.days, err = parseRange(qs.Get("days"), "days", defaultForecastDays, h.Settings.MaxDays)
+	case "hourly":
+		request.hourly = true
+		request.hours, err = parseRange(qs.Get("hours"), "hours", defaultForecastHours, h.Settings.MaxHours)
+	default:
+		return request, errors.New("invalid mode, must be daily or hourly")
+	}
+
+	if err != nil {
+		return request, err
+	}
+
+	switch qs.Get("timezone") {
+	case "", "local":
+	case "utc":
+		request.utc = true
+	default:
+		return request, errors.New("invalid timezone, must be local or utc")
+	}
+
+	return request, nil
+}
+
+// parseRange defaults to fallback, capped at limit, when value is not informed.
+func parseRange(value string, name string, fallback int, limit int) (int, error) {
+	if value == "" {
+		return min(fallback, limit), nil
+	}
+
+	parsed, err := strconv.Atoi(value)
+	if err != nil || parsed < 1 || parsed > limit {
+		return 0, fmt.Errorf("invalid %s, must be between 1 and %d", name, limit)
+	}
+
+	return parsed, nil
+}
+
+func forecastDayOutput(day entities.ForecastDay) dto.ForecastDayOutput {
+	return dto.ForecastDayOutput{
+		Date:      day.Date,
+		Min:       temperatureOutput(day.MinTempC),
+		Max:       temperatureOutput(day.MaxTempC),
+		Avg:       temperatureOutput(day.AvgTempC),
+		Condition: day.Condition,
+	}
+}
+
+func temperatureOutput(celcius float64) dto.TemperatureOutput {
+	fahrenheit, kelvin := convertTemperature(celcius)
+
+	return dto.TemperatureOutput{
+		Celcius:    float32(celcius),
+		Fahrenheit: float32(fahrenheit),
+		Kelvin:     float32(kelvin),
+	}
+}
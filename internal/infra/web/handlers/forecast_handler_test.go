@@ -0,0 +1,235 @@
+package handlers
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+
+	"github.com/wellalencarweb/otel-lab-challenge/internal/entities"
+	"github.com/wellalencarweb/otel-lab-challenge/internal/pkg/customerrors"
+	"github.com/wellalencarweb/otel-lab-challenge/internal/pkg/mocks"
+	"github.com/wellalencarweb/otel-lab-challenge/internal/pkg/responsehandler"
+	"github.com/wellalencarweb/otel-lab-challenge/internal/usecases/climate"
+)
+
+type ForecastHandlerTestSuite struct {
+	suite.Suite
+	FindLocationByZipCodeUseCaseMock  *mocks.FindByZipCodeUseCaseMock
+	FindForecastByCityNameUseCaseMock *mocks.FindForecastByCityNameUseCaseMock
+	FindHourlyForecastUseCaseMock     *mocks.FindHourlyForecastByCityNameUseCaseMock
+	Recorder                          *tracetest.SpanRecorder
+	WebForecastHandler                *WebForecastHandler
+}
+
+func TestForecastHandler(t *testing.T) {
+	suite.Run(t, new(ForecastHandlerTestSuite))
+}
+
+func (s *ForecastHandlerTestSuite) SetupTest() {
+	s.FindLocationByZipCodeUseCaseMock = new(mocks.FindByZipCodeUseCaseMock)
+	s.FindForecastByCityNameUseCaseMock = new(mocks.FindForecastByCityNameUseCaseMock)
+	s.FindHourlyForecastUseCaseMock = new(mocks.FindHourlyForecastByCityNameUseCaseMock)
+	s.Recorder = tracetest.NewSpanRecorder()
+
+	s.WebForecastHandler = NewWebForecastHandler(
+		responsehandler.NewWebResponseHandler(),
+		s.FindLocationByZipCodeUseCaseMock,
+		s.FindForecastByCityNameUseCaseMock,
+		s.FindHourlyForecastUseCaseMock,
+		ForecastSettings{MaxDays: 7, MaxHours: 48},
+		sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(s.Recorder)).Tracer("forecast-test"),
+	)
+}
+
+func (s *ForecastHandlerTestSuite) get(target string) (int, string) {
+	req := httptest.NewRequest(http.MethodGet, target, nil)
+	w := httptest.NewRecorder()
+
+	s.WebForecastHandler.GetForecastByZipCode(w, req)
+
+	res := w.Result()
+	defer res.Body.Close()
+
+	data, _ := io.ReadAll(res.Body)
+
+	return res.StatusCode, strings.TrimSuffix(string(data), "\n")
+}
+
+func (s *ForecastHandlerTestSuite) TestGetForecastByZipCode() {
+	location := &entities.Location{City: "Rio de Janeiro", State: "RJ", Zipcode: "22021001"}
+
+	s.Run("should return the daily forecast by zipcode", func() {
+		s.SetupTest()
+		s.FindLocationByZipCodeUseCaseMock.On("Execute", mock.Anything, "22021001").Return(location, nil)
+		s.FindForecastByCityNameUseCaseMock.On("Execute", mock.Anything, "Rio de Janeiro", "RJ", 2).Return(&entities.Forecast{
+			Location: entities.ClimateLocation{Latitude: -22.9068, Longitude: -43.1729},
+			Days: []entities.ForecastDay{
+				{Date: "2025-10-17", MinTempC: 20, MaxTempC: 30, AvgTempC: 25, Condition: "Sunny"},
+				{Date: "2025-10-18", MinTempC: 18, MaxTempC: 22, AvgTempC: 20, Condition: "Patchy rain nearby"},
+			},
+		}, nil)
+
+		status, body := s.get("/forecast?zipcode=22021001&days=2")
+
+		s.Equal(http.StatusOK, status)
+		s.Equal(
+			"{\"city\":\"Rio de Janeiro\",\"lat\":-22.9068,\"lon\":-43.1729,\"days\":["+
+				"{\"date\":\"2025-10-17\",\"min\":{\"temp_C\":20,\"temp_F\":68,\"temp_K\":293.15},"+
+				"\"max\":{\"temp_C\":30,\"temp_F\":86,\"temp_K\":303.15},"+
+				"\"avg\":{\"temp_C\":25,\"temp_F\":77,\"temp_K\":298.15},\"condition\":\"Sunny\"},"+
+				"{\"date\":\"2025-10-18\",\"min\":{\"temp_C\":18,\"temp_F\":64.4,\"temp_K\":291.15},"+
+				"\"max\":{\"temp_C\":22,\"temp_F\":71.6,\"temp_K\":295.15},"+
+				"\"avg\":{\"temp_C\":20,\"temp_F\":68,\"temp_K\":293.15},\"condition\":\"Patchy rain nearby\"}]}",
+			body,
+		)
+
+		var names []string
+		for _, span := range s.Recorder.Ended() {
+			names = append(names, span.Name())
+		}
+		s.Equal([]string{"find-location-by-zipcode", "find-forecast-by-city-name", "forecast"}, names)
+	})
+
+	s.Run("should default to three days", func() {
+		s.SetupTest()
+		s.FindLocationByZipCodeUseCaseMock.On("Execute", mock.Anything, "22021001").Return(location, nil)
+		s.FindForecastByCityNameUseCaseMock.On("Execute", mock.Anything, "Rio de Janeiro", "RJ", 3).Return(&entities.Forecast{}, nil)
+
+		status, body := s.get("/forecast?zipcode=22021001")
+
+		s.Equal(http.StatusOK, status)
+		s.Equal("{\"city\":\"Rio de Janeiro\",\"days\":[]}", body)
+	})
+
+	s.Run("should reject days out of range", func() {
+		for _, days := range []string{"0", "8", "tomorrow"} {
+			s.SetupTest()
+
+			status, body := s.get("/forecast?zipcode=22021001&days=" + days)
+
+			s.Equal(http.StatusUnprocessableEntity, status)
+			s.Equal("{\"message\":\"invalid days, must be between 1 and 7\"}", body)
+			s.FindLocationByZipCodeUseCaseMock.AssertNotCalled(s.T(), "Execute", mock.Anything, mock.Anything)
+		}
+	})
+
+	s.Run("should reject invalid zipcodes", func() {
+		s.SetupTest()
+
+		status, _ := s.get("/forecast?zipcode=123")
+
+		s.Equal(http.StatusUnprocessableEntity, status)
+	})
+
+	s.Run("should return not found when the zipcode does not exist", func() {
+		s.SetupTest()
+		s.FindLocationByZipCodeUseCaseMock.On("Execute", mock.Anything, "99999999").Return(&entities.Location{}, nil)
+
+		status, body := s.get("/forecast?zipcode=99999999")
+
+		s.Equal(http.StatusNotFound, status)
+		s.Equal("{\"message\":\"zipcode not found\"}", body)
+	})
+
+	s.Run("should return service unavailable when the forecast upstream is down", func() {
+		s.SetupTest()
+		s.FindLocationByZipCodeUseCaseMock.On("Execute", mock.Anything, "22021001").Return(location, nil)
+		s.FindForecastByCityNameUseCaseMock.On("Execute", mock.Anything, "Rio de Janeiro", "RJ", 3).Return((*entities.Forecast)(nil), &customerrors.ServiceUnavailableError{
+			Err:     errors.New("circuit breaker is open"),
+			Message: "weatherapi is unavailable",
+		})
+
+		status, _ := s.get("/forecast?zipcode=22021001")
+
+		s.Equal(http.StatusServiceUnavailable, status)
+	})
+}
+
+func (s *ForecastHandlerTestSuite) TestGetHourlyForecastByZipCode() {
+	location := &entities.Location{City: "Rio Branco", State: "AC", Zipcode: "69900062"}
+	rioBranco, err := time.LoadLocation("America/Rio_Branco")
+	s.Require().NoError(err)
+
+	forecast := &entities.Forecast{
+		Location: entities.ClimateLocation{TimeZone: "America/Rio_Branco"},
+		Hours: []entities.ForecastHour{
+			{Time: time.Date(2025, 10, 17, 22, 0, 0, 0, rioBranco), TempC: 26, Condition: "Clear sky"},
+			{Time: time.Date(2025, 10, 17, 23, 0, 0, 0, rioBranco), TempC: 25, Condition: "Clear sky"},
+		},
+	}
+
+	s.Run("should return the hourly forecast in the location's time zone", func() {
+		s.SetupTest()
+		s.FindLocationByZipCodeUseCaseMock.On("Execute", mock.Anything, "69900062").Return(location, nil)
+		s.FindHourlyForecastUseCaseMock.On("Execute", mock.Anything, "Rio Branco", "AC", 2).Return(forecast, nil)
+
+		status, body := s.get("/forecast?zipcode=69900062&mode=hourly&hours=2")
+
+		s.Equal(http.StatusOK, status)
+		s.Equal(
+			"{\"city\":\"Rio Branco\",\"tz_id\":\"America/Rio_Branco\",\"hours\":["+
+				"{\"time\":\"2025-10-17T22:00:00-05:00\",\"temp_C\":26,\"temp_F\":78.8,\"temp_K\":299.15,\"condition\":\"Clear sky\"},"+
+				"{\"time\":\"2025-10-17T23:00:00-05:00\",\"temp_C\":25,\"temp_F\":77,\"temp_K\":298.15,\"condition\":\"Clear sky\"}]}",
+			body,
+		)
+		s.Equal("find-hourly-forecast-by-city-name", s.Recorder.Ended()[1].Name())
+	})
+
+	s.Run("should return the hourly forecast in UTC when asked to", func() {
+		s.SetupTest()
+		s.FindLocationByZipCodeUseCaseMock.On("Execute", mock.Anything, "69900062").Return(location, nil)
+		s.FindHourlyForecastUseCaseMock.On("Execute", mock.Anything, "Rio Branco", "AC", 24).Return(forecast, nil)
+
+		status, body := s.get("/forecast?zipcode=69900062&mode=hourly&timezone=utc")
+
+		s.Equal(http.StatusOK, status)
+		s.Contains(body, "\"tz_id\":\"UTC\"")
+		s.Contains(body, "\"time\":\"2025-10-18T03:00:00Z\"")
+		s.Contains(body, "\"time\":\"2025-10-18T04:00:00Z\"")
+	})
+
+	s.Run("should label the hours as UTC when the provider's time zone is unknown", func() {
+		s.SetupTest()
+
+		providerMock := new(mocks.WeatherProviderMock)
+		providerMock.On("Name").Return("weatherapi")
+		providerMock.On("HourlyForecast", mock.Anything, mock.Anything, 1).Return(&entities.Forecast{
+			Location: entities.ClimateLocation{TimeZone: "Mars/Olympus_Mons"},
+			Hours:    []entities.ForecastHour{{Time: time.Date(2025, 10, 17, 22, 0, 0, 0, rioBranco), TempC: 26, Condition: "Clear sky"}},
+		}, nil)
+
+		s.WebForecastHandler.FindHourlyForecastByCityNameUseCase = climate.NewFindHourlyForecastByCityNameUseCase(providerMock, nil, zerolog.Nop())
+		s.FindLocationByZipCodeUseCaseMock.On("Execute", mock.Anything, "69900062").Return(location, nil)
+
+		status, body := s.get("/forecast?zipcode=69900062&mode=hourly&hours=1")
+
+		s.Equal(http.StatusOK, status)
+		s.Contains(body, "\"tz_id\":\"UTC\"")
+		s.Contains(body, "\"time\":\"2025-10-18T03:00:00Z\"")
+	})
+
+	s.Run("should reject invalid hourly requests", func() {
+		for target, message := range map[string]string{
+			"/forecast?zipcode=69900062&mode=hourly&hours=49":   "invalid hours, must be between 1 and 48",
+			"/forecast?zipcode=69900062&mode=weekly":            "invalid mode, must be daily or hourly",
+			"/forecast?zipcode=69900062&mode=hourly&timezone=x": "invalid timezone, must be local or utc",
+		} {
+			s.SetupTest()
+
+			status, body := s.get(target)
+
+			s.Equal(http.StatusUnprocessableEntity, status)
+			s.Equal("{\"message\":\""+message+"\"}", body)
+		}
+	})
+}
@@ -17,22 +17,26 @@ import (
 
 type WebInputHandlerInterface interface {
 	Handle(w http.ResponseWriter, r *http.Request)
+	HandleBatch(w http.ResponseWriter, r *http.Request)
 }
 
 type WebInputHandler struct {
 	ResponseHandler responsehandler.WebResponseHandlerInterface
 	InputUseCase    input.InputUseCaseInterface
+	BatchUseCase    input.BatchUseCaseInterface
 	Tracer          trace.Tracer
 }
 
 func NewWebInputHandler(
 	rh responsehandler.WebResponseHandlerInterface,
 	inputUC input.InputUseCaseInterface,
+	batchUC input.BatchUseCaseInterface,
 	tracer trace.Tracer,
 ) *WebInputHandler {
 	return &WebInputHandler{
 		ResponseHandler: rh,
 		InputUseCase:    inputUC,
+		BatchUseCase:    batchUC,
 		Tracer:          tracer,
 	}
 }
@@ -53,33 +57,65 @@ func (h *WebInputHandler) Handle(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	otel.GetTextMapPropagator().Inject(ctx, propagation.HeaderCarrier(r.Header))
-
 	input, err := h.InputUseCase.Execute(ctx, dto)
 	if err != nil {
-		switch err.(type) {
-		case *customerrors.NotFoundError:
-			recordSpan(span, err, "not found")
-
-			h.ResponseHandler.RespondWithError(w, http.StatusNotFound, err)
-			return
-		case *customerrors.ValidationError:
-			recordSpan(span, err, "invalid zipcode")
-
-			h.ResponseHandler.RespondWithError(w, http.StatusUnprocessableEntity, err)
-			return
-		case *customerrors.UnknownError:
-		default:
-			recordSpan(span, err, "error getting location")
-
-			h.ResponseHandler.RespondWithError(w, http.StatusInternalServerError, err)
-			return
-		}
+		h.respondWithError(w, span, err)
+		return
 	}
 
 	h.ResponseHandler.Respond(w, http.StatusOK, input)
 }
 
+func (h *WebInputHandler) HandleBatch(w http.ResponseWriter, r *http.Request) {
+	var batch dto.BatchInput
+
+	carrier := propagation.HeaderCarrier(r.Header)
+	ctx := otel.GetTextMapPropagator().Extract(r.Context(), carrier)
+	ctx, span := h.Tracer.Start(ctx, "batch")
+	defer span.End()
+
+	if err := json.NewDecoder(r.Body).Decode(&batch); err != nil {
+		recordSpan(span, err, "error decoding request body")
+
+		h.ResponseHandler.RespondWithError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	output, err := h.BatchUseCase.Execute(ctx, batch)
+	if err != nil {
+		h.respondWithError(w, span, err)
+		return
+	}
+
+	h.ResponseHandler.Respond(w, http.StatusOK, output)
+}
+
+func (h *WebInputHandler) respondWithError(w http.ResponseWriter, span trace.Span, err error) {
+	switch err.(type) {
+	case *customerrors.NotFoundError:
+		recordSpan(span, err, "not found")
+
+		h.ResponseHandler.RespondWithError(w, http.StatusNotFound, err)
+	case *customerrors.ValidationError:
+		recordSpan(span, err, "invalid zipcode")
+
+		h.ResponseHandler.RespondWithError(w, http.StatusUnprocessableEntity, err)
+	case *customerrors.TooManyRequestsError:
+		recordSpan(span, err, "rate limit exceeded")
+
+		writeRetryAfter(w, err)
+		h.ResponseHandler.RespondWithError(w, http.StatusTooManyRequests, err)
+	case *customerrors.ServiceUnavailableError:
+		recordSpan(span, err, "upstream unavailable")
+
+		h.ResponseHandler.RespondWithError(w, http.StatusServiceUnavailable, err)
+	default:
+		recordSpan(span, err, "error getting location")
+
+		h.ResponseHandler.RespondWithError(w, http.StatusInternalServerError, err)
+	}
+}
+
 func recordSpan(span trace.Span, err error, description string) {
 	span.SetStatus(codes.Error, description)
 	span.RecordError(err)
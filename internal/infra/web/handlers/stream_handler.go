@@ -0,0 +1,194 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/wellalencarweb/otel-lab-challenge/internal/entities/dto"
+	"github.com/wellalencarweb/otel-lab-challenge/internal/pkg/responsehandler"
+	"github.com/wellalencarweb/otel-lab-challenge/internal/usecases/climate"
+	"github.com/wellalencarweb/otel-lab-challenge/internal/usecases/location"
+)
+
+const defaultStreamHeartbeatInterval = 15 * time.Second
+
+type WebStreamHandlerInterface interface {
+	StreamTemperaturesByZipCode(w http.ResponseWriter, r *http.Request)
+}
+
+type WebStreamHandler struct {
+	ResponseHandler              responsehandler.WebResponseHandlerInterface
+	FindLocationByZipCodeUseCase location.FindByZipCodeUseCaseInterface
+	WatchByCityNameUseCase       climate.WatchByCityNameUseCaseInterface
+	HeartbeatInterval            time.Duration
+	Tracer                       trace.Tracer
+}
+
+func NewWebStreamHandler(
+	rh responsehandler.WebResponseHandlerInterface,
+	findByZipCodeUC location.FindByZipCodeUseCaseInterface,
+	watchByCityNameUC climate.WatchByCityNameUseCaseInterface,
+	heartbeatInterval time.Duration,
+	tracer trace.Tracer,
+) *WebStreamHandler {
+	if heartbeatInterval <= 0 {
+		heartbeatInterval = defaultStreamHeartbeatInterval
+	}
+
+	return &WebStreamHandler{
+		ResponseHandler:              rh,
+		FindLocationByZipCodeUseCase: findByZipCodeUC,
+		WatchByCityNameUseCase:       watchByCityNameUC,
+		HeartbeatInterval:            heartbeatInterval,
+		Tracer:                       tracer,
+	}
+}
+
+// StreamTemperaturesByZipCode keeps the connection open as a Server-Sent Events stream, sending
+// a temperature event whenever the reading of the zipcode's city changes, an error event when
+// it can not be read, and a heartbeat comment while nothing changes. It accepts the same fields
+// as GetTemperaturesByZipCode.
+func (h *WebStreamHandler) StreamTemperaturesByZipCode(w http.ResponseWriter, r *http.Request) {
+	carrier := propagation.HeaderCarrier(r.Header)
+	ctx := otel.GetTextMapPropagator().Extract(r.Context(), carrier)
+	ctx, span := h.Tracer.Start(ctx, "stream")
+	defer span.End()
+
+	qs := r.URL.Query()
+	zipStr := qs.Get("zipcode")
+
+	if err := validateInput(zipStr); err != nil {
+		span.SetStatus(codes.Error, "invalid zipcode")
+		span.RecordError(err)
+
+		h.ResponseHandler.RespondWithError(w, http.StatusUnprocessableEntity, err)
+		return
+	}
+
+	fields, err := dto.ParseTemperatureFields(qs.Get("fields"), qs.Get("expand"))
+	if err != nil {
+		span.SetStatus(codes.Error, "invalid fields")
+		span.RecordError(err)
+
+		h.ResponseHandler.RespondWithError(w, http.StatusUnprocessableEntity, err)
+		return
+	}
+
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		err := errors.New("streaming unsupported")
+		span.SetStatus(codes.Error, "streaming unsupported")
+		span.RecordError(err)
+
+		h.ResponseHandler.RespondWithError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	zipCodeCtx, zipCodeSpan := h.Tracer.Start(ctx, "find-location-by-zipcode")
+	location, err := h.FindLocationByZipCodeUseCase.Execute(zipCodeCtx, zipStr)
+	if err != nil {
+		zipCodeSpan.SetStatus(codes.Error, "error finding location by zipcode")
+		zipCodeSpan.RecordError(err)
+		zipCodeSpan.End()
+
+		writeRetryAfter(w, err)
+		h.ResponseHandler.RespondWithError(w, errorStatusCode(err), err)
+		return
+	}
+	if location.City == "" {
+		zipCodeSpan.SetStatus(codes.Error, "zipcode not found")
+		zipCodeSpan.End()
+
+		h.ResponseHandler.RespondWithError(w, http.StatusNotFound, errors.New("zipcode not found"))
+		return
+	}
+
+	zipCodeSpan.End()
+
+	events, err := h.WatchByCityNameUseCase.Subscribe(ctx, location.City, location.State)
+	if err != nil {
+		span.SetStatus(codes.Error, "error subscribing to climate")
+		span.RecordError(err)
+
+		h.ResponseHandler.RespondWithError(w, errorStatusCode(err), err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.Header().Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	heartbeat := time.NewTicker(h.HeartbeatInterval)
+	defer heartbeat.Stop()
+
+	sent := 0
+	defer func() { span.SetAttributes(attribute.Int("stream.events", sent)) }()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-heartbeat.C:
+			if _, err := fmt.Fprint(w, ": heartbeat\n\n"); err != nil {
+				return
+			}
+		case event, ok := <-events:
+			if !ok {
+				return
+			}
+
+			var err error
+			if event.Err != nil {
+				err = writeStreamEvent(w, "error", "", map[string]string{"message": event.Err.Error()})
+			} else {
+				var id string
+				if observedAt := event.Climate.Current.ObservedAt; !observedAt.IsZero() {
+					id = strconv.FormatInt(observedAt.Unix(), 10)
+				}
+
+				err = writeStreamEvent(w, "temperature", id, temperaturesOutput(location, event.Climate, fields))
+			}
+
+			if err != nil {
+				span.RecordError(err)
+				return
+			}
+
+			sent++
+			heartbeat.Reset(h.HeartbeatInterval)
+		}
+
+		flusher.Flush()
+	}
+}
+
+// writeStreamEvent writes one Server-Sent Event with data encoded as a single JSON line.
+func writeStreamEvent(w http.ResponseWriter, name string, id string, data interface{}) error {
+	payload, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+
+	if id != "" {
+		if _, err := fmt.Fprintf(w, "id: %s\n", id); err != nil {
+			return err
+		}
+	}
+
+	_, err = fmt.Fprintf(w, "event: %s\ndata: %s\n\n", name, payload)
+
+	return err
+}
@@ -0,0 +1,136 @@
+package handlers
+
+import (
+	"bufio"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"go.opentelemetry.io/otel"
+
+	"github.com/wellalencarweb/otel-lab-challenge/internal/entities"
+	"github.com/wellalencarweb/otel-lab-challenge/internal/pkg/mocks"
+	"github.com/wellalencarweb/otel-lab-challenge/internal/pkg/responsehandler"
+	"github.com/wellalencarweb/otel-lab-challenge/internal/usecases/climate"
+)
+
+type StreamHandlerTestSuite struct {
+	suite.Suite
+	FindLocationByZipCodeUseCaseMock *mocks.FindByZipCodeUseCaseMock
+	FindClimateByCityNameUseCaseMock *mocks.FindByCityNameUseCaseMock
+	WatchByCityNameUseCase           *climate.WatchByCityNameUseCase
+	Server                           *httptest.Server
+}
+
+func TestStreamHandler(t *testing.T) {
+	suite.Run(t, new(StreamHandlerTestSuite))
+}
+
+func (s *StreamHandlerTestSuite) SetupTest() {
+	tracer := otel.Tracer("stream-test")
+
+	s.FindLocationByZipCodeUseCaseMock = new(mocks.FindByZipCodeUseCaseMock)
+	s.FindClimateByCityNameUseCaseMock = new(mocks.FindByCityNameUseCaseMock)
+	s.WatchByCityNameUseCase = climate.NewWatchByCityNameUseCase(s.FindClimateByCityNameUseCaseMock, climate.WatchSettings{
+		Interval:       time.Hour,
+		MaxSubscribers: 1,
+	}, zerolog.Nop(), tracer)
+
+	handler := NewWebStreamHandler(
+		responsehandler.NewWebResponseHandler(),
+		s.FindLocationByZipCodeUseCaseMock,
+		s.WatchByCityNameUseCase,
+		20*time.Millisecond,
+		tracer,
+	)
+
+	s.Server = httptest.NewServer(http.HandlerFunc(handler.StreamTemperaturesByZipCode))
+}
+
+func (s *StreamHandlerTestSuite) TearDownTest() {
+	s.WatchByCityNameUseCase.Close()
+	s.Server.Close()
+}
+
+func (s *StreamHandlerTestSuite) get(query string) *http.Response {
+	res, err := http.Get(s.Server.URL + query)
+	s.Require().NoError(err)
+
+	return res
+}
+
+func (s *StreamHandlerTestSuite) TestStreamTemperaturesByZipCode() {
+	s.FindLocationByZipCodeUseCaseMock.On("Execute", mock.Anything, "69900000").Return(&entities.Location{
+		Zipcode: "69900-000",
+		City:    "Rio Branco",
+		State:   "AC",
+	}, nil)
+	s.FindClimateByCityNameUseCaseMock.On("Execute", mock.Anything, "Rio Branco", "AC").Return(&entities.Climate{
+		Current: entities.ClimateData{
+			ObservedAt: time.Unix(1760720400, 0),
+			TempC:      30,
+			Humidity:   70,
+		},
+	}, nil)
+
+	s.Run("should push the temperature and then heartbeats", func() {
+		res := s.get("/?zipcode=69900000&fields=humidity")
+		defer res.Body.Close()
+
+		s.Equal(http.StatusOK, res.StatusCode)
+		s.Equal("text/event-stream", res.Header.Get("Content-Type"))
+
+		reader := bufio.NewReader(res.Body)
+		readLine := func() string {
+			line, err := reader.ReadString('\n')
+			s.Require().NoError(err)
+
+			return strings.TrimSuffix(line, "\n")
+		}
+
+		s.Equal("id: 1760720400", readLine())
+		s.Equal("event: temperature", readLine())
+		s.Equal("data: {\"city\":\"Rio Branco\",\"temp_C\":30,\"temp_F\":86,\"temp_K\":303.15,\"humidity\":70}", readLine())
+		s.Equal("", readLine())
+		s.Equal(": heartbeat", readLine())
+	})
+
+	s.Run("should free the subscription when the client disconnects and refuse subscribers over the limit", func() {
+		var open *http.Response
+		s.Require().Eventually(func() bool {
+			res := s.get("/?zipcode=69900000")
+			if res.StatusCode == http.StatusOK {
+				open = res
+				return true
+			}
+
+			res.Body.Close()
+			return false
+		}, time.Second, 10*time.Millisecond)
+		defer open.Body.Close()
+
+		res := s.get("/?zipcode=69900000")
+		defer res.Body.Close()
+
+		data, _ := io.ReadAll(res.Body)
+
+		s.Equal(http.StatusServiceUnavailable, res.StatusCode)
+		s.Equal("{\"message\":\"too many subscribers\"}", strings.TrimSuffix(string(data), "\n"))
+	})
+
+	s.Run("should return error when zipcode is invalid", func() {
+		res := s.get("/?zipcode=123")
+		defer res.Body.Close()
+
+		data, _ := io.ReadAll(res.Body)
+
+		s.Equal(http.StatusUnprocessableEntity, res.StatusCode)
+		s.Equal("{\"message\":\"invalid zipcode\"}", strings.TrimSuffix(string(data), "\n"))
+	})
+}
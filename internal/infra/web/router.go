@@ -15,7 +15,14 @@ type InputWebRouter struct {
 }
 
 type OrchestratorWebRouter struct {
-	WebClimateHandler handlers.WebClimateHandlerInterface
+	WebClimateHandler  handlers.WebClimateHandlerInterface
+	WebForecastHandler handlers.WebForecastHandlerInterface
+	WebBatchHandler    handlers.WebBatchHandlerInterface
+	WebStreamHandler   handlers.WebStreamHandlerInterface
+}
+
+type AdminWebRouter struct {
+	WebAdminHandler handlers.WebAdminHandlerInterface
 }
 
 func NewInputWebRouter(webInputHandler handlers.WebInputHandlerInterface) *InputWebRouter {
@@ -24,9 +31,23 @@ func NewInputWebRouter(webInputHandler handlers.WebInputHandlerInterface) *Input
 	}
 }
 
-func NewOrchestratorWebRouter(webClimateHandler handlers.WebClimateHandlerInterface) *OrchestratorWebRouter {
+func NewOrchestratorWebRouter(
+	webClimateHandler handlers.WebClimateHandlerInterface,
+	webForecastHandler handlers.WebForecastHandlerInterface,
+	webBatchHandler handlers.WebBatchHandlerInterface,
+	webStreamHandler handlers.WebStreamHandlerInterface,
+) *OrchestratorWebRouter {
 	return &OrchestratorWebRouter{
-		WebClimateHandler: webClimateHandler,
+		WebClimateHandler:  webClimateHandler,
+		WebForecastHandler: webForecastHandler,
+		WebBatchHandler:    webBatchHandler,
+		WebStreamHandler:   webStreamHandler,
+	}
+}
+
+func NewAdminWebRouter(webAdminHandler handlers.WebAdminHandlerInterface) *AdminWebRouter {
+	return &AdminWebRouter{
+		WebAdminHandler: webAdminHandler,
 	}
 }
 
@@ -37,6 +58,11 @@ func (wr *InputWebRouter) Build() []RouteHandler {
 			Method:      http.MethodPost,
 			HandlerFunc: wr.WebInputHandler.Handle,
 		},
+		{
+			Path:        "/batch",
+			Method:      http.MethodPost,
+			HandlerFunc: wr.WebInputHandler.HandleBatch,
+		},
 	}
 }
 
@@ -47,5 +73,56 @@ func (wr *OrchestratorWebRouter) Build() []RouteHandler {
 			Method:      http.MethodGet,
 			HandlerFunc: wr.WebClimateHandler.GetTemperaturesByZipCode,
 		},
+		{
+			Path:        "/forecast",
+			Method:      http.MethodGet,
+			HandlerFunc: wr.WebForecastHandler.GetForecastByZipCode,
+		},
+		{
+			Path:        "/batch",
+			Method:      http.MethodPost,
+			HandlerFunc: wr.WebBatchHandler.GetTemperaturesByZipCodes,
+		},
+		{
+			Path:        "/stream",
+			Method:      http.MethodGet,
+			HandlerFunc: wr.WebStreamHandler.StreamTemperaturesByZipCode,
+		},
+	}
+}
+
+func (wr *AdminWebRouter) Build() []RouteHandler {
+	routes := []RouteHandler{
+		{
+			Path:        "/admin/caches",
+			Method:      http.MethodGet,
+			HandlerFunc: wr.WebAdminHandler.GetCacheStats,
+		},
+		{
+			Path:        "/admin/caches/{cache}/keys/{key}",
+			Method:      http.MethodGet,
+			HandlerFunc: wr.WebAdminHandler.GetCacheEntry,
+		},
+		{
+			Path:        "/admin/caches/{cache}/keys/{key}",
+			Method:      http.MethodDelete,
+			HandlerFunc: wr.WebAdminHandler.PurgeCacheEntry,
+		},
+		{
+			Path:        "/admin/caches/{cache}/keys",
+			Method:      http.MethodDelete,
+			HandlerFunc: wr.WebAdminHandler.PurgeCacheEntries,
+		},
+		{
+			Path:        "/admin/caches/warmup",
+			Method:      http.MethodPost,
+			HandlerFunc: wr.WebAdminHandler.WarmUpCaches,
+		},
+	}
+
+	for i := range routes {
+		routes[i].HandlerFunc = wr.WebAdminHandler.Authorize(routes[i].HandlerFunc)
 	}
+
+	return routes
 }
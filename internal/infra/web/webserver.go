@@ -28,6 +28,8 @@ type WebServer struct {
 	Handlers      []RouteHandler
 	WebServerPort int
 	Logger        zerolog.Logger
+
+	onShutdown []func()
 }
 
 func NewWebServer(serverPort int, logger zerolog.Logger, handlers []RouteHandler) *WebServer {
@@ -40,6 +42,12 @@ func NewWebServer(serverPort int, logger zerolog.Logger, handlers []RouteHandler
 	}
 }
 
+// RegisterOnShutdown calls f when Shutdown starts, so long-lived responses such as event streams
+// can end instead of holding the shutdown until its deadline.
+func (s *WebServer) RegisterOnShutdown(f func()) {
+	s.onShutdown = append(s.onShutdown, f)
+}
+
 func (s *WebServer) Start() {
 	s.Router.Use(chizero.LoggerMiddleware(&s.Logger))
 	s.Router.Use(middleware.RequestID)
@@ -58,6 +66,10 @@ func (s *WebServer) Start() {
 		Handler: s.Router,
 	}
 
+	for _, f := range s.onShutdown {
+		s.Server.RegisterOnShutdown(f)
+	}
+
 	go func() {
 		if err := s.Server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
 			s.Logger.Fatal().Err(err).Msg("Failed to start webserver")
@@ -0,0 +1,63 @@
+package cache
+
+import "time"
+
+// Backend is the storage behind the use case caches. LRU keeps entries in memory only, while
+// FileStore also persists them so they survive restarts.
+type Backend[V any] interface {
+	Get(key string) (V, bool)
+	Peek(key string) (V, time.Time, bool)
+	Set(key string, value V, ttl time.Duration)
+	Delete(key string) bool
+	DeletePrefix(prefix string) []string
+	Len() int
+	Stats() Stats
+}
+
+var (
+	_ Backend[any] = (*LRU[any])(nil)
+	_ Backend[any] = (*FileStore[any])(nil)
+)
+
+type Entry struct {
+	Key       string      `json:"key"`
+	Value     interface{} `json:"value"`
+	ExpiresAt time.Time   `json:"expires_at"`
+}
+
+// Inspector gives the admin API a type-erased view over a Backend.
+type Inspector interface {
+	Stats() Stats
+	Inspect(key string) (Entry, bool)
+	Purge(key string) bool
+	PurgePrefix(prefix string) int
+}
+
+type inspector[V any] struct {
+	backend Backend[V]
+}
+
+func NewInspector[V any](backend Backend[V]) Inspector {
+	return inspector[V]{backend: backend}
+}
+
+func (i inspector[V]) Stats() Stats {
+	return i.backend.Stats()
+}
+
+func (i inspector[V]) Inspect(key string) (Entry, bool) {
+	value, expiresAt, ok := i.backend.Peek(key)
+	if !ok {
+		return Entry{}, false
+	}
+
+	return Entry{Key: key, Value: value, ExpiresAt: expiresAt}, true
+}
+
+func (i inspector[V]) Purge(key string) bool {
+	return i.backend.Delete(key)
+}
+
+func (i inspector[V]) PurgePrefix(prefix string) int {
+	return len(i.backend.DeletePrefix(prefix))
+}
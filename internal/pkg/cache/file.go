@@ -0,0 +1,279 @@
+package cache
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog"
+)
+
+type record[V any] struct {
+	Key       string    `json:"key"`
+	Value     *V        `json:"value,omitempty"`
+	ExpiresAt time.Time `json:"expires_at,omitempty"`
+}
+
+// FileStore is an LRU backed by an append-only log of JSON lines. Every write, and every
+// eviction, is appended to the log and reads are served from memory; on open, the log is
+// replayed to warm the cache up. Compact rewrites the log keeping only the live entries,
+// dropping overwritten, deleted, evicted and expired ones.
+type FileStore[V any] struct {
+	Path   string
+	Logger zerolog.Logger
+
+	memory  *LRU[V]
+	mu      sync.Mutex
+	file    *os.File
+	records int
+	stop    chan struct{}
+	done    chan struct{}
+}
+
+func OpenFileStore[V any](path string, maxEntries int, logger zerolog.Logger) (*FileStore[V], error) {
+	store := &FileStore[V]{
+		Path:   path,
+		Logger: logger,
+		memory: NewLRU[V](maxEntries),
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return nil, err
+	}
+
+	if err := store.load(); err != nil {
+		return nil, err
+	}
+
+	// evictions are logged as deletes, so a reload with more room does not bring them back;
+	// memory only evicts within Set, which already holds mu
+	store.memory.onEvict = func(key string) {
+		store.append(record[V]{Key: key})
+	}
+
+	if err := store.Compact(); err != nil {
+		return nil, err
+	}
+
+	store.Logger.Info().Msgf("[FileStore] Loaded [%d] entries from [%s]", store.Len(), path)
+
+	return store, nil
+}
+
+func (s *FileStore[V]) Get(key string) (V, bool) {
+	return s.memory.Get(key)
+}
+
+// Set, Delete and DeletePrefix update memory and the log under the same lock, so concurrent
+// writes to a key reach the log in the order memory applied them and a replay ends on the
+// same value.
+func (s *FileStore[V]) Set(key string, value V, ttl time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.memory.Set(key, value, ttl)
+	s.append(record[V]{Key: key, Value: &value, ExpiresAt: s.memory.now().Add(ttl)})
+}
+
+func (s *FileStore[V]) Peek(key string) (V, time.Time, bool) {
+	return s.memory.Peek(key)
+}
+
+func (s *FileStore[V]) Delete(key string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	deleted := s.memory.Delete(key)
+	s.append(record[V]{Key: key})
+
+	return deleted
+}
+
+func (s *FileStore[V]) DeletePrefix(prefix string) []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	deleted := s.memory.DeletePrefix(prefix)
+	for _, key := range deleted {
+		s.append(record[V]{Key: key})
+	}
+
+	return deleted
+}
+
+func (s *FileStore[V]) Stats() Stats {
+	return s.memory.Stats()
+}
+
+func (s *FileStore[V]) Len() int {
+	return s.memory.Len()
+}
+
+func (s *FileStore[V]) Each(fn func(key string, value V, expiresAt time.Time)) {
+	s.memory.Each(fn)
+}
+
+// Compact rewrites the log with the entries currently in memory, through a temporary file
+// renamed over the log so a crash never leaves it half written.
+func (s *FileStore[V]) Compact() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	tmp, err := os.CreateTemp(filepath.Dir(s.Path), filepath.Base(s.Path)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	writer := bufio.NewWriter(tmp)
+	encoder := json.NewEncoder(writer)
+	records := 0
+
+	s.memory.Each(func(key string, value V, expiresAt time.Time) {
+		if err == nil {
+			err = encoder.Encode(record[V]{Key: key, Value: &value, ExpiresAt: expiresAt})
+			records++
+		}
+	})
+
+	if err == nil {
+		err = writer.Flush()
+	}
+
+	if err == nil {
+		err = tmp.Sync()
+	}
+
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+
+	if err != nil {
+		return err
+	}
+
+	if err := os.Rename(tmp.Name(), s.Path); err != nil {
+		return err
+	}
+
+	if s.file != nil {
+		s.file.Close()
+	}
+
+	s.file, err = os.OpenFile(s.Path, os.O_APPEND|os.O_WRONLY, 0o644)
+	s.records = records
+
+	return err
+}
+
+// StartCompaction compacts the log every interval, whenever it holds more records than live
+// entries, until Close is called. Expired entries memory has not collected yet are not live.
+func (s *FileStore[V]) StartCompaction(interval time.Duration) {
+	s.stop = make(chan struct{})
+	s.done = make(chan struct{})
+
+	go func() {
+		defer close(s.done)
+
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-s.stop:
+				return
+			case <-ticker.C:
+				s.mu.Lock()
+				garbage := s.records > s.memory.live()
+				s.mu.Unlock()
+
+				if !garbage {
+					continue
+				}
+
+				if err := s.Compact(); err != nil {
+					s.Logger.Error().Msgf("[FileStore] Error compacting [%s]: %s", s.Path, err)
+				}
+			}
+		}
+	}()
+}
+
+func (s *FileStore[V]) Close() error {
+	if s.stop != nil {
+		close(s.stop)
+		<-s.done
+	}
+
+	compactErr := s.Compact()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// the log is closed even when the final compaction fails, as it is still complete
+	var closeErr error
+	if s.file != nil {
+		closeErr = s.file.Close()
+		s.file = nil
+	}
+
+	return errors.Join(compactErr, closeErr)
+}
+
+func (s *FileStore[V]) load() error {
+	file, err := os.Open(s.Path)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1<<20)
+
+	s.memory.mu.Lock()
+	defer s.memory.mu.Unlock()
+
+	now := s.memory.now()
+
+	for scanner.Scan() {
+		var r record[V]
+		if err := json.Unmarshal(scanner.Bytes(), &r); err != nil {
+			// a crash may leave a truncated last line behind
+			s.Logger.Warn().Msgf("[FileStore] Skipping unreadable record in [%s]: %s", s.Path, err)
+			continue
+		}
+
+		if element, ok := s.memory.entries[r.Key]; ok {
+			s.memory.remove(element)
+		}
+
+		if r.Value != nil && now.Before(r.ExpiresAt) {
+			s.memory.set(r.Key, *r.Value, r.ExpiresAt)
+		}
+	}
+
+	return scanner.Err()
+}
+
+// append writes the record to the log; callers hold mu.
+func (s *FileStore[V]) append(r record[V]) {
+	content, err := json.Marshal(r)
+	if err != nil {
+		s.Logger.Error().Msgf("[FileStore] Error encoding record for [%s]: %s", r.Key, err)
+		return
+	}
+
+	if _, err := s.file.Write(append(content, '\n')); err != nil {
+		s.Logger.Error().Msgf("[FileStore] Error writing record for [%s] to [%s]: %s", r.Key, s.Path, err)
+		return
+	}
+
+	s.records++
+}
@@ -0,0 +1,192 @@
+package cache
+
+import (
+	"bufio"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/suite"
+)
+
+type FileStoreTestSuite struct {
+	suite.Suite
+	Path string
+}
+
+func TestFileStore(t *testing.T) {
+	suite.Run(t, new(FileStoreTestSuite))
+}
+
+func (s *FileStoreTestSuite) SetupTest() {
+	s.Path = filepath.Join(s.T().TempDir(), "cache", "locations.jsonl")
+}
+
+func (s *FileStoreTestSuite) open() *FileStore[string] {
+	store, err := OpenFileStore[string](s.Path, 10, zerolog.Nop())
+	s.Require().NoError(err)
+
+	return store
+}
+
+func (s *FileStoreTestSuite) lines() int {
+	file, err := os.Open(s.Path)
+	s.Require().NoError(err)
+	defer file.Close()
+
+	lines := 0
+	for scanner := bufio.NewScanner(file); scanner.Scan(); {
+		lines++
+	}
+
+	return lines
+}
+
+func (s *FileStoreTestSuite) TestWarmLoad() {
+	store := s.open()
+	store.Set("22021001", "Rio de Janeiro", time.Hour)
+	store.Set("01001000", "São Paulo", time.Hour)
+	store.Set("expired", "Atlantis", time.Millisecond)
+	store.Set("deleted", "Atlantis", time.Hour)
+	store.Delete("deleted")
+	s.Require().NoError(store.file.Close())
+
+	time.Sleep(5 * time.Millisecond)
+
+	store = s.open()
+	defer store.Close()
+
+	value, ok := store.Get("22021001")
+	s.True(ok)
+	s.Equal("Rio de Janeiro", value)
+
+	value, ok = store.Get("01001000")
+	s.True(ok)
+	s.Equal("São Paulo", value)
+
+	_, ok = store.Get("expired")
+	s.False(ok)
+
+	_, ok = store.Get("deleted")
+	s.False(ok)
+
+	s.Equal(2, store.Len())
+	s.Equal(2, s.lines())
+}
+
+func (s *FileStoreTestSuite) TestConcurrentSetsReplayInOrder() {
+	store := s.open()
+
+	// large values widen the window between updating memory and encoding the record
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+
+			for j := 0; j < 20; j++ {
+				store.Set("22021001", strings.Repeat(strconv.Itoa(i*20+j), 10000), time.Hour)
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	want, ok := store.Get("22021001")
+	s.Require().True(ok)
+	s.Require().NoError(store.file.Close())
+
+	store = s.open()
+	defer store.Close()
+
+	value, ok := store.Get("22021001")
+	s.True(ok)
+	s.Equal(want, value)
+}
+
+func (s *FileStoreTestSuite) TestSkipsTruncatedRecords() {
+	store := s.open()
+	store.Set("22021001", "Rio de Janeiro", time.Hour)
+	s.Require().NoError(store.file.Close())
+
+	file, err := os.OpenFile(s.Path, os.O_APPEND|os.O_WRONLY, 0o644)
+	s.Require().NoError(err)
+	_, err = file.WriteString(`{"key":"01001000","val`)
+	s.Require().NoError(err)
+	s.Require().NoError(file.Close())
+
+	store = s.open()
+	defer store.Close()
+
+	s.Equal(1, store.Len())
+}
+
+func (s *FileStoreTestSuite) TestCompaction() {
+	store := s.open()
+
+	for i := 0; i < 5; i++ {
+		store.Set("22021001", "Rio de Janeiro", time.Hour)
+	}
+	s.Equal(5, s.lines())
+
+	store.StartCompaction(5 * time.Millisecond)
+
+	s.Eventually(func() bool {
+		return s.lines() == 1
+	}, time.Second, 5*time.Millisecond)
+
+	store.Set("01001000", "São Paulo", time.Hour)
+	s.Require().NoError(store.Close())
+
+	s.Equal(2, s.lines())
+}
+
+func (s *FileStoreTestSuite) TestCompactsExpiredEntries() {
+	store := s.open()
+	defer store.Close()
+
+	store.Set("22021001", "Rio de Janeiro", time.Millisecond)
+	time.Sleep(5 * time.Millisecond)
+
+	// the expired entry is still held in memory, as nothing looked it up
+	s.Equal(1, store.Len())
+
+	store.StartCompaction(5 * time.Millisecond)
+
+	s.Eventually(func() bool {
+		return s.lines() == 0
+	}, time.Second, 5*time.Millisecond)
+}
+
+func (s *FileStoreTestSuite) TestEvictionsSurviveReload() {
+	store, err := OpenFileStore[string](s.Path, 2, zerolog.Nop())
+	s.Require().NoError(err)
+
+	store.Set("22021001", "Rio de Janeiro", time.Hour)
+	store.Set("01001000", "São Paulo", time.Hour)
+	store.Set("69900062", "Rio Branco", time.Hour)
+	s.Require().NoError(store.file.Close())
+
+	store = s.open()
+	defer store.Close()
+
+	_, ok := store.Get("22021001")
+	s.False(ok)
+	s.Equal(2, store.Len())
+}
+
+func (s *FileStoreTestSuite) TestCloseWhenCompactionFails() {
+	store := s.open()
+	store.Set("22021001", "Rio de Janeiro", time.Hour)
+
+	file := store.file
+	s.Require().NoError(os.RemoveAll(filepath.Dir(s.Path)))
+
+	s.Error(store.Close())
+	s.Nil(store.file)
+	s.ErrorIs(file.Close(), os.ErrClosed)
+}
@@ -0,0 +1,212 @@
+package cache
+
+import (
+	"container/list"
+	"strings"
+	"sync"
+	"time"
+)
+
+type entry[V any] struct {
+	key       string
+	value     V
+	expiresAt time.Time
+}
+
+type Stats struct {
+	Entries   int     `json:"entries"`
+	Hits      uint64  `json:"hits"`
+	Misses    uint64  `json:"misses"`
+	Evictions uint64  `json:"evictions"`
+	HitRatio  float64 `json:"hit_ratio"`
+}
+
+// LRU is a size-bounded in-memory cache where every entry carries its own TTL. When full,
+// the least recently used entry is evicted; expired entries are dropped on access.
+type LRU[V any] struct {
+	MaxEntries int
+
+	mu        sync.Mutex
+	entries   map[string]*list.Element
+	order     *list.List
+	now       func() time.Time
+	onEvict   func(key string)
+	hits      uint64
+	misses    uint64
+	evictions uint64
+}
+
+func NewLRU[V any](maxEntries int) *LRU[V] {
+	return &LRU[V]{
+		MaxEntries: max(maxEntries, 1),
+		entries:    make(map[string]*list.Element),
+		order:      list.New(),
+		now:        time.Now,
+	}
+}
+
+func (c *LRU[V]) Get(key string) (V, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	element, ok := c.lookup(key)
+	if !ok {
+		c.misses++
+
+		var zero V
+		return zero, false
+	}
+
+	c.hits++
+	c.order.MoveToFront(element)
+
+	return element.Value.(*entry[V]).value, true
+}
+
+// Peek returns an entry and its expiration without touching its recency or the hit ratio.
+func (c *LRU[V]) Peek(key string) (V, time.Time, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	element, ok := c.lookup(key)
+	if !ok {
+		var zero V
+		return zero, time.Time{}, false
+	}
+
+	e := element.Value.(*entry[V])
+
+	return e.value, e.expiresAt, true
+}
+
+func (c *LRU[V]) Set(key string, value V, ttl time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.set(key, value, c.now().Add(ttl))
+}
+
+func (c *LRU[V]) set(key string, value V, expiresAt time.Time) {
+	if element, ok := c.entries[key]; ok {
+		e := element.Value.(*entry[V])
+		e.value = value
+		e.expiresAt = expiresAt
+		c.order.MoveToFront(element)
+
+		return
+	}
+
+	c.entries[key] = c.order.PushFront(&entry[V]{key: key, value: value, expiresAt: expiresAt})
+
+	for c.order.Len() > c.MaxEntries {
+		evicted := c.order.Back()
+		c.remove(evicted)
+		c.evictions++
+
+		if c.onEvict != nil {
+			c.onEvict(evicted.Value.(*entry[V]).key)
+		}
+	}
+}
+
+func (c *LRU[V]) Delete(key string) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	element, ok := c.entries[key]
+	if ok {
+		c.remove(element)
+	}
+
+	return ok
+}
+
+func (c *LRU[V]) DeletePrefix(prefix string) []string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	var deleted []string
+	for key, element := range c.entries {
+		if strings.HasPrefix(key, prefix) {
+			c.remove(element)
+			deleted = append(deleted, key)
+		}
+	}
+
+	return deleted
+}
+
+func (c *LRU[V]) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.order.Len()
+}
+
+// live counts the entries that have not expired, including those not collected yet.
+func (c *LRU[V]) live() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := c.now()
+	live := 0
+
+	for _, element := range c.entries {
+		if now.Before(element.Value.(*entry[V]).expiresAt) {
+			live++
+		}
+	}
+
+	return live
+}
+
+func (c *LRU[V]) Stats() Stats {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	stats := Stats{
+		Entries:   c.order.Len(),
+		Hits:      c.hits,
+		Misses:    c.misses,
+		Evictions: c.evictions,
+	}
+
+	if lookups := c.hits + c.misses; lookups > 0 {
+		stats.HitRatio = float64(c.hits) / float64(lookups)
+	}
+
+	return stats
+}
+
+// Each calls fn for every live entry, from the least to the most recently used.
+func (c *LRU[V]) Each(fn func(key string, value V, expiresAt time.Time)) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := c.now()
+
+	for element := c.order.Back(); element != nil; element = element.Prev() {
+		if e := element.Value.(*entry[V]); now.Before(e.expiresAt) {
+			fn(e.key, e.value, e.expiresAt)
+		}
+	}
+}
+
+func (c *LRU[V]) lookup(key string) (*list.Element, bool) {
+	element, ok := c.entries[key]
+	if !ok {
+		return nil, false
+	}
+
+	if !c.now().Before(element.Value.(*entry[V]).expiresAt) {
+		c.remove(element)
+		return nil, false
+	}
+
+	return element, true
+}
+
+func (c *LRU[V]) remove(element *list.Element) {
+	c.order.Remove(element)
+	delete(c.entries, element.Value.(*entry[V]).key)
+}
@@ -0,0 +1,105 @@
+package cache
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type LRUTestSuite struct {
+	suite.Suite
+	Now time.Time
+}
+
+func TestLRU(t *testing.T) {
+	suite.Run(t, new(LRUTestSuite))
+}
+
+func (s *LRUTestSuite) newLRU(maxEntries int) *LRU[string] {
+	s.Now = time.Now()
+
+	lru := NewLRU[string](maxEntries)
+	lru.now = func() time.Time { return s.Now }
+
+	return lru
+}
+
+func (s *LRUTestSuite) TestGet() {
+	s.Run("should return stored values until they expire", func() {
+		lru := s.newLRU(10)
+		lru.Set("22021001", "Rio de Janeiro", time.Minute)
+
+		value, ok := lru.Get("22021001")
+		s.True(ok)
+		s.Equal("Rio de Janeiro", value)
+
+		s.Now = s.Now.Add(time.Minute)
+
+		_, ok = lru.Get("22021001")
+		s.False(ok)
+		s.Equal(0, lru.Len())
+	})
+
+	s.Run("should refresh value and ttl when set again", func() {
+		lru := s.newLRU(10)
+		lru.Set("22021001", "Rio", time.Second)
+		lru.Set("22021001", "Rio de Janeiro", time.Minute)
+
+		s.Now = s.Now.Add(30 * time.Second)
+
+		value, ok := lru.Get("22021001")
+		s.True(ok)
+		s.Equal("Rio de Janeiro", value)
+		s.Equal(1, lru.Len())
+	})
+}
+
+func (s *LRUTestSuite) TestEviction() {
+	lru := s.newLRU(2)
+	lru.Set("a", "1", time.Minute)
+	lru.Set("b", "2", time.Minute)
+
+	_, ok := lru.Get("a")
+	s.True(ok)
+
+	lru.Set("c", "3", time.Minute)
+
+	_, ok = lru.Get("b")
+	s.False(ok)
+
+	_, ok = lru.Get("a")
+	s.True(ok)
+
+	_, ok = lru.Get("c")
+	s.True(ok)
+
+	lru.Delete("a")
+	s.Equal(1, lru.Len())
+}
+
+func (s *LRUTestSuite) TestStats() {
+	lru := s.newLRU(2)
+	lru.Set("a", "1", time.Minute)
+	lru.Set("b", "2", time.Minute)
+	lru.Set("c", "3", time.Minute)
+
+	lru.Get("c")
+	lru.Get("a")
+	lru.Peek("b")
+
+	s.Equal(Stats{Entries: 2, Hits: 1, Misses: 1, Evictions: 1, HitRatio: 0.5}, lru.Stats())
+}
+
+func (s *LRUTestSuite) TestDeletePrefix() {
+	lru := s.newLRU(10)
+	lru.Set("rio de janeiro|rj", "1", time.Minute)
+	lru.Set("rio branco|ac", "2", time.Minute)
+	lru.Set("sao paulo|sp", "3", time.Minute)
+
+	s.ElementsMatch([]string{"rio de janeiro|rj", "rio branco|ac"}, lru.DeletePrefix("rio"))
+	s.Equal(1, lru.Len())
+
+	_, ok := lru.Get("sao paulo|sp")
+	s.True(ok)
+}
@@ -9,3 +9,7 @@ type NotFoundError struct {
 func (e *NotFoundError) Error() string {
 	return e.Message
 }
+
+func (e *NotFoundError) Unwrap() error {
+	return e.Err
+}
@@ -0,0 +1,18 @@
+package customerrors
+
+import "time"
+
+type TooManyRequestsError struct {
+	Err        error
+	Message    string
+	RetryAfter time.Duration
+	Tags       map[string]interface{}
+}
+
+func (e *TooManyRequestsError) Error() string {
+	return e.Message
+}
+
+func (e *TooManyRequestsError) Unwrap() error {
+	return e.Err
+}
@@ -0,0 +1,15 @@
+package customerrors
+
+type ServiceUnavailableError struct {
+	Err     error
+	Message string
+	Tags    map[string]interface{}
+}
+
+func (e *ServiceUnavailableError) Error() string {
+	return e.Message
+}
+
+func (e *ServiceUnavailableError) Unwrap() error {
+	return e.Err
+}
@@ -9,3 +9,7 @@ type UnknownError struct {
 func (e *UnknownError) Error() string {
 	return e.Message
 }
+
+func (e *UnknownError) Unwrap() error {
+	return e.Err
+}
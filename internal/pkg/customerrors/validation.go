@@ -10,3 +10,7 @@ type ValidationError struct {
 func (e *ValidationError) Error() string {
 	return e.Message
 }
+
+func (e *ValidationError) Unwrap() error {
+	return e.Err
+}
@@ -1,17 +1,25 @@
 package dependencies
 
 import (
+	"io"
+	"net/http"
+	"os"
+	"strings"
 	"time"
 
+	"github.com/rs/zerolog"
 	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/trace"
 
 	"github.com/wellalencarweb/otel-lab-challenge/config"
+	"github.com/wellalencarweb/otel-lab-challenge/internal/entities"
 	"github.com/wellalencarweb/otel-lab-challenge/internal/infra/web"
 	"github.com/wellalencarweb/otel-lab-challenge/internal/infra/web/handlers"
+	"github.com/wellalencarweb/otel-lab-challenge/internal/pkg/cache"
 	"github.com/wellalencarweb/otel-lab-challenge/internal/pkg/httpclient"
 	"github.com/wellalencarweb/otel-lab-challenge/internal/pkg/logger"
 	"github.com/wellalencarweb/otel-lab-challenge/internal/pkg/responsehandler"
+	"github.com/wellalencarweb/otel-lab-challenge/internal/usecases/bulk"
 	"github.com/wellalencarweb/otel-lab-challenge/internal/usecases/climate"
 	"github.com/wellalencarweb/otel-lab-challenge/internal/usecases/input"
 	"github.com/wellalencarweb/otel-lab-challenge/internal/usecases/location"
@@ -23,14 +31,27 @@ type InputServiceDependencies struct {
 }
 
 type OrchestratorServiceDependencies struct {
-	ServiceName string
-	WebServer   web.WebServerInterface
+	ServiceName    string
+	WebServer      web.WebServerInterface
+	AdminWebServer web.WebServerInterface
+	Closers        []io.Closer
+}
+
+type BulkResolveDependencies struct {
+	ServiceName            string
+	ResolveZipCodesUseCase bulk.ResolveZipCodesUseCaseInterface
+	Logger                 zerolog.Logger
+	Closers                []io.Closer
 }
 
 type sharedDependencies struct {
 	ResponseHandler   responsehandler.WebResponseHandler
 	Logger            logger.Logger
 	HttpClientTimeout time.Duration
+	RetryPolicy       httpclient.RetryPolicy
+	BreakerSettings   httpclient.CircuitBreakerSettings
+	Transport         http.RoundTripper
+	MaxBodySize       int64
 	Tracer            trace.Tracer
 }
 
@@ -38,11 +59,38 @@ func ResolveInputServiceDependencies(config *config.Conf) InputServiceDependenci
 	serviceName := "input-service"
 	sharedDeps := resolveSharedDependencies(config, serviceName)
 
-	httpClient := httpclient.NewHttpClient(config.OrchestratorServiceHost, sharedDeps.HttpClientTimeout)
+	// single lookups and batches share one limiter, so together they stay within the configured rate
+	limiter := httpclient.NewRateLimiter(
+		"orchestrator-service",
+		resolveRateLimitSettings(config.OrchestratorRateLimitRPS, config.OrchestratorRateLimitBurst, config.OrchestratorRateLimitMode),
+	)
+
+	httpClient := newUpstreamHttpClient(
+		"orchestrator-service",
+		config.OrchestratorServiceHost,
+		httpclient.RateLimitSettings{},
+		sharedDeps,
+		httpclient.WithRateLimiter(limiter),
+	)
+
+	// a batch takes longer than a single lookup, so it gets a client with its own timeout
+	batchDeps := sharedDeps
+	if config.BatchTimeout > 0 {
+		batchDeps.HttpClientTimeout = time.Duration(config.BatchTimeout) * time.Millisecond
+	}
+
+	batchHttpClient := newUpstreamHttpClient(
+		"orchestrator-service-batch",
+		config.OrchestratorServiceHost,
+		httpclient.RateLimitSettings{},
+		batchDeps,
+		httpclient.WithRateLimiter(limiter),
+	)
 
 	inputUC := input.NewInputUseCase(httpClient, sharedDeps.Logger.GetLogger())
+	batchUC := input.NewBatchUseCase(batchHttpClient, resolveBatchMaxSize(config), sharedDeps.Logger.GetLogger())
 
-	webInputHandler := handlers.NewWebInputHandler(&sharedDeps.ResponseHandler, inputUC, sharedDeps.Tracer)
+	webInputHandler := handlers.NewWebInputHandler(&sharedDeps.ResponseHandler, inputUC, batchUC, sharedDeps.Tracer)
 
 	webRouter := web.NewInputWebRouter(webInputHandler)
 	webServer := web.NewWebServer(config.InputServiceWebServerPort, sharedDeps.Logger.GetLogger(), webRouter.Build())
@@ -56,21 +104,192 @@ func ResolveInputServiceDependencies(config *config.Conf) InputServiceDependenci
 func ResolveOrchestratorServiceDependencies(config *config.Conf) OrchestratorServiceDependencies {
 	serviceName := "orchestrator-service"
 	sharedDeps := resolveSharedDependencies(config, serviceName)
+	useCases := resolveClimateUseCases(config, sharedDeps)
+
+	webClimateHandler := handlers.NewWebClimateHandler(&sharedDeps.ResponseHandler, useCases.FindByZipCodeUseCase, useCases.FindByCityNameUseCase, sharedDeps.Tracer)
 
-	viaCepAPIHttpClient := httpclient.NewHttpClient(config.ViaCepApiBaseUrl, sharedDeps.HttpClientTimeout)
-	weatherAPIHttpClient := httpclient.NewHttpClient(config.WeatherApiBaseUrl, sharedDeps.HttpClientTimeout)
+	webForecastHandler := handlers.NewWebForecastHandler(
+		&sharedDeps.ResponseHandler,
+		useCases.FindByZipCodeUseCase,
+		useCases.FindForecastByCityNameUseCase,
+		useCases.FindHourlyForecastByCityNameUseCase,
+		handlers.ForecastSettings{
+			MaxDays:  config.ForecastMaxDays,
+			MaxHours: config.ForecastMaxHours,
+		},
+		sharedDeps.Tracer,
+	)
 
-	findByZipCodeUseCase := location.NewFindByZipCodeUseCase(viaCepAPIHttpClient, sharedDeps.Logger.GetLogger())
-	findByCityNameUseCase := climate.NewFindByCityNameUseCase(weatherAPIHttpClient, sharedDeps.Logger.GetLogger(), config.WeatherApiKey)
+	resolveZipCodesUC := bulk.NewResolveZipCodesUseCase(
+		useCases.FindByZipCodeUseCase,
+		useCases.FindByCityNameUseCase,
+		nil,
+		bulk.Settings{Concurrency: config.BatchConcurrency},
+		sharedDeps.Logger.GetLogger(),
+		sharedDeps.Tracer,
+	)
 
-	webClimateHandler := handlers.NewWebClimateHandler(&sharedDeps.ResponseHandler, findByZipCodeUseCase, findByCityNameUseCase, sharedDeps.Tracer)
+	webBatchHandler := handlers.NewWebBatchHandler(&sharedDeps.ResponseHandler, resolveZipCodesUC, resolveBatchMaxSize(config), sharedDeps.Tracer)
 
-	webRouter := web.NewOrchestratorWebRouter(webClimateHandler)
+	webStreamHandler := handlers.NewWebStreamHandler(
+		&sharedDeps.ResponseHandler,
+		useCases.FindByZipCodeUseCase,
+		useCases.WatchByCityNameUseCase,
+		time.Duration(config.StreamHeartbeatInterval)*time.Millisecond,
+		sharedDeps.Tracer,
+	)
+
+	webRouter := web.NewOrchestratorWebRouter(webClimateHandler, webForecastHandler, webBatchHandler, webStreamHandler)
 	webServer := web.NewWebServer(config.OrchestratorServiceWebServerPort, sharedDeps.Logger.GetLogger(), webRouter.Build())
 
+	// open streams would otherwise hold the graceful shutdown until its deadline
+	webServer.RegisterOnShutdown(func() { useCases.WatchByCityNameUseCase.Close() })
+
+	// the admin listener is only started when a token is configured
+	var adminWebServer web.WebServerInterface
+	if config.AdminToken != "" {
+		webAdminHandler := handlers.NewWebAdminHandler(
+			&sharedDeps.ResponseHandler,
+			config.AdminToken,
+			useCases.Caches,
+			useCases.FindByZipCodeUseCase,
+			useCases.FindByCityNameUseCase,
+			config.AdminWarmupConcurrency,
+			resolveBatchMaxSize(config),
+			sharedDeps.Tracer,
+		)
+
+		adminWebRouter := web.NewAdminWebRouter(webAdminHandler)
+		adminWebServer = web.NewWebServer(config.AdminWebServerPort, sharedDeps.Logger.GetLogger(), adminWebRouter.Build())
+	}
+
 	return OrchestratorServiceDependencies{
-		ServiceName: serviceName,
-		WebServer:   webServer,
+		ServiceName:    serviceName,
+		WebServer:      webServer,
+		AdminWebServer: adminWebServer,
+		Closers:        useCases.Closers,
+	}
+}
+
+type BulkResolveSettings struct {
+	Concurrency      int
+	RateLimitRPS     float64
+	ProgressInterval time.Duration
+}
+
+func ResolveBulkResolveDependencies(config *config.Conf, settings BulkResolveSettings) BulkResolveDependencies {
+	serviceName := "bulkresolve"
+	sharedDeps := resolveSharedDependencies(config, serviceName)
+
+	// results may be written to stdout, so logs go to stderr
+	sharedDeps.Logger.Out = os.Stderr
+	sharedDeps.Logger.Setup()
+
+	// a running orchestrator may own the cache file, and two processes appending to and
+	// compacting the same log would corrupt it
+	bulkConfig := *config
+	bulkConfig.LocationCacheBackend = "memory"
+
+	useCases := resolveClimateUseCases(&bulkConfig, sharedDeps)
+
+	limiter := httpclient.NewRateLimiter(serviceName, httpclient.RateLimitSettings{
+		Rate:  settings.RateLimitRPS,
+		Burst: 1,
+		Mode:  httpclient.RateLimitWait,
+	})
+
+	resolveZipCodesUC := bulk.NewResolveZipCodesUseCase(
+		useCases.FindByZipCodeUseCase,
+		useCases.FindByCityNameUseCase,
+		limiter,
+		bulk.Settings{
+			Concurrency:      settings.Concurrency,
+			ProgressInterval: settings.ProgressInterval,
+		},
+		sharedDeps.Logger.GetLogger(),
+		sharedDeps.Tracer,
+	)
+
+	return BulkResolveDependencies{
+		ServiceName:            serviceName,
+		ResolveZipCodesUseCase: resolveZipCodesUC,
+		Logger:                 sharedDeps.Logger.GetLogger(),
+		Closers:                useCases.Closers,
+	}
+}
+
+type climateUseCases struct {
+	FindByZipCodeUseCase                location.FindByZipCodeUseCaseInterface
+	FindByCityNameUseCase               climate.FindByCityNameUseCaseInterface
+	FindForecastByCityNameUseCase       climate.FindForecastByCityNameUseCaseInterface
+	FindHourlyForecastByCityNameUseCase climate.FindHourlyForecastByCityNameUseCaseInterface
+	WatchByCityNameUseCase              *climate.WatchByCityNameUseCase
+	Caches                              map[string]cache.Inspector
+	Closers                             []io.Closer
+}
+
+// resolveClimateUseCases builds the zipcode and climate lookups, along with their upstream
+// clients and caches, shared by the orchestrator and the bulk resolver.
+func resolveClimateUseCases(config *config.Conf, sharedDeps sharedDependencies) climateUseCases {
+	var findByZipCodeUseCase location.FindByZipCodeUseCaseInterface = location.NewCoalescedFindByZipCodeUseCase(
+		location.NewFindByZipCodeUseCase(resolveLocationProviders(config, sharedDeps), sharedDeps.Logger.GetLogger()),
+	)
+	var closers []io.Closer
+	caches := make(map[string]cache.Inspector)
+	if config.LocationCacheTTL > 0 {
+		backend, closer := resolveLocationCacheBackend(config, sharedDeps.Logger.GetLogger())
+		if closer != nil {
+			closers = append(closers, closer)
+		}
+		caches[handlers.LocationsCache] = cache.NewInspector(backend)
+
+		findByZipCodeUseCase = location.NewCachedFindByZipCodeUseCase(findByZipCodeUseCase, backend, location.CacheSettings{
+			TTL:         time.Duration(config.LocationCacheTTL) * time.Millisecond,
+			NegativeTTL: time.Duration(config.LocationCacheNegativeTTL) * time.Millisecond,
+		}, sharedDeps.Logger.GetLogger())
+	}
+
+	geocoder := climate.Geocoder(climate.NewOpenMeteoGeocoder(
+		newUpstreamHttpClient("openmeteo-geocoding", config.OpenMeteoGeocodingBaseUrl, httpclient.RateLimitSettings{}, sharedDeps),
+	))
+	if config.GeocodingCacheTTL > 0 {
+		backend := cache.NewLRU[entities.ClimateLocation](config.GeocodingCacheMaxEntries)
+		caches[handlers.GeocodingCache] = cache.NewInspector[entities.ClimateLocation](backend)
+
+		geocoder = climate.NewCachedGeocoder(geocoder, backend, time.Duration(config.GeocodingCacheTTL)*time.Millisecond)
+	}
+
+	weatherProvider := resolveWeatherProviders(config, geocoder, sharedDeps)
+	queryGeocoder := resolveGeocoder(config, geocoder, sharedDeps.Logger.GetLogger())
+
+	var findByCityNameUseCase climate.FindByCityNameUseCaseInterface = climate.NewCoalescedFindByCityNameUseCase(
+		climate.NewFindByCityNameUseCase(weatherProvider, queryGeocoder, sharedDeps.Logger.GetLogger()),
+	)
+
+	// streams poll upstream on their own interval, so they skip the climate cache
+	watchByCityNameUseCase := climate.NewWatchByCityNameUseCase(findByCityNameUseCase, climate.WatchSettings{
+		Interval:       time.Duration(config.StreamPollInterval) * time.Millisecond,
+		MaxSubscribers: config.StreamMaxSubscribers,
+	}, sharedDeps.Logger.GetLogger(), sharedDeps.Tracer)
+	if config.ClimateCacheTTL > 0 {
+		backend := cache.NewLRU[climate.CachedClimate](config.ClimateCacheMaxEntries)
+		caches[handlers.ClimatesCache] = cache.NewInspector[climate.CachedClimate](backend)
+
+		findByCityNameUseCase = climate.NewCachedFindByCityNameUseCase(findByCityNameUseCase, backend, climate.CacheSettings{
+			TTL:                  time.Duration(config.ClimateCacheTTL) * time.Millisecond,
+			StaleWhileRevalidate: time.Duration(config.ClimateCacheStaleWhileRevalidate) * time.Millisecond,
+			StaleIfError:         time.Duration(config.ClimateCacheStaleIfError) * time.Millisecond,
+		}, sharedDeps.Logger.GetLogger())
+	}
+
+	return climateUseCases{
+		FindByZipCodeUseCase:                findByZipCodeUseCase,
+		FindByCityNameUseCase:               findByCityNameUseCase,
+		FindForecastByCityNameUseCase:       climate.NewFindForecastByCityNameUseCase(weatherProvider, queryGeocoder, sharedDeps.Logger.GetLogger()),
+		FindHourlyForecastByCityNameUseCase: climate.NewFindHourlyForecastByCityNameUseCase(weatherProvider, queryGeocoder, sharedDeps.Logger.GetLogger()),
+		WatchByCityNameUseCase:              watchByCityNameUseCase,
+		Caches:                              caches,
+		Closers:                             closers,
 	}
 }
 
@@ -88,6 +307,290 @@ func resolveSharedDependencies(config *config.Conf, serviceName string) sharedDe
 		ResponseHandler:   *responseHandler,
 		Logger:            *logger,
 		HttpClientTimeout: httpClientTimeout,
+		RetryPolicy:       resolveRetryPolicy(config),
+		BreakerSettings:   resolveBreakerSettings(config),
+		Transport:         httpclient.NewTransport(resolveTransportSettings(config)),
+		MaxBodySize:       config.HttpClientMaxResponseBodyBytes,
 		Tracer:            tracer,
 	}
 }
+
+func newUpstreamHttpClient(
+	name string,
+	baseURL string,
+	rateLimit httpclient.RateLimitSettings,
+	sharedDeps sharedDependencies,
+	opts ...httpclient.ClientOption,
+) *httpclient.HttpClient {
+	breaker := httpclient.NewCircuitBreaker(name, sharedDeps.BreakerSettings, sharedDeps.Logger.GetLogger())
+
+	opts = append([]httpclient.ClientOption{
+		httpclient.WithRetryPolicy(sharedDeps.RetryPolicy),
+		httpclient.WithCircuitBreaker(breaker),
+		httpclient.WithTransport(sharedDeps.Transport),
+		httpclient.WithMaxBodySize(sharedDeps.MaxBodySize),
+		httpclient.WithRateLimiter(httpclient.NewRateLimiter(name, rateLimit)),
+	}, opts...)
+
+	return httpclient.NewHttpClient(name, baseURL, sharedDeps.HttpClientTimeout, sharedDeps.Tracer, opts...)
+}
+
+func resolveRetryPolicy(config *config.Conf) httpclient.RetryPolicy {
+	policy := httpclient.DefaultRetryPolicy()
+
+	if config.HttpClientRetryMaxAttempts > 0 {
+		policy.MaxAttempts = config.HttpClientRetryMaxAttempts
+	}
+
+	if config.HttpClientRetryBaseDelay > 0 {
+		policy.BaseDelay = time.Duration(config.HttpClientRetryBaseDelay) * time.Millisecond
+	}
+
+	if config.HttpClientRetryMaxDelay > 0 {
+		policy.MaxDelay = time.Duration(config.HttpClientRetryMaxDelay) * time.Millisecond
+	}
+
+	if config.HttpClientRetryJitter > 0 {
+		policy.Jitter = config.HttpClientRetryJitter
+	}
+
+	if len(config.HttpClientRetryStatusCodes) > 0 {
+		policy.RetryableStatusCodes = config.HttpClientRetryStatusCodes
+	}
+
+	return policy
+}
+
+func resolveBreakerSettings(config *config.Conf) httpclient.CircuitBreakerSettings {
+	settings := httpclient.DefaultCircuitBreakerSettings()
+
+	if config.HttpClientBreakerFailureRatio > 0 {
+		settings.FailureRatio = config.HttpClientBreakerFailureRatio
+	}
+
+	if config.HttpClientBreakerWindowSize > 0 {
+		settings.WindowSize = config.HttpClientBreakerWindowSize
+	}
+
+	if config.HttpClientBreakerCoolDown > 0 {
+		settings.CoolDown = time.Duration(config.HttpClientBreakerCoolDown) * time.Millisecond
+	}
+
+	return settings
+}
+
+func resolveTransportSettings(config *config.Conf) httpclient.TransportSettings {
+	settings := httpclient.DefaultTransportSettings()
+
+	if config.HttpClientMaxIdleConns > 0 {
+		settings.MaxIdleConns = config.HttpClientMaxIdleConns
+	}
+
+	if config.HttpClientMaxIdleConnsPerHost > 0 {
+		settings.MaxIdleConnsPerHost = config.HttpClientMaxIdleConnsPerHost
+	}
+
+	if config.HttpClientMaxConnsPerHost > 0 {
+		settings.MaxConnsPerHost = config.HttpClientMaxConnsPerHost
+	}
+
+	if config.HttpClientIdleConnTimeout > 0 {
+		settings.IdleConnTimeout = time.Duration(config.HttpClientIdleConnTimeout) * time.Millisecond
+	}
+
+	if config.HttpClientTLSHandshakeTimeout > 0 {
+		settings.TLSHandshakeTimeout = time.Duration(config.HttpClientTLSHandshakeTimeout) * time.Millisecond
+	}
+
+	if config.HttpClientResponseHeaderTimeout > 0 {
+		settings.ResponseHeaderTimeout = time.Duration(config.HttpClientResponseHeaderTimeout) * time.Millisecond
+	}
+
+	settings.ForceAttemptHTTP2 = !config.HttpClientDisableHTTP2
+
+	return settings
+}
+
+func resolveRateLimitSettings(rps float64, burst int, mode string) httpclient.RateLimitSettings {
+	settings := httpclient.RateLimitSettings{
+		Rate:  rps,
+		Burst: burst,
+		Mode:  httpclient.RateLimitWait,
+	}
+
+	if httpclient.RateLimitMode(mode) == httpclient.RateLimitReject {
+		settings.Mode = httpclient.RateLimitReject
+	}
+
+	return settings
+}
+
+// resolveBatchMaxSize keeps the input service and the orchestrator agreeing on the batch limit
+// when BATCH_MAX_SIZE is not set.
+func resolveBatchMaxSize(config *config.Conf) int {
+	if config.BatchMaxSize > 0 {
+		return config.BatchMaxSize
+	}
+
+	return handlers.DefaultBatchMaxSize
+}
+
+func resolveHedgeOptions(delay int, percentile float64) []httpclient.ClientOption {
+	if delay <= 0 && percentile <= 0 {
+		return nil
+	}
+
+	hedger := httpclient.NewHedger(httpclient.HedgePolicy{
+		Delay:      time.Duration(delay) * time.Millisecond,
+		Percentile: percentile,
+	})
+
+	return []httpclient.ClientOption{httpclient.WithHedging(hedger)}
+}
+
+// resolveLocationProviders chains the providers listed in LOCATION_PROVIDERS, in order, skipping
+// unknown names. ViaCEP alone is used when none of them is known.
+func resolveLocationProviders(config *config.Conf, sharedDeps sharedDependencies) location.LocationProvider {
+	logger := sharedDeps.Logger.GetLogger()
+
+	var providers []location.ChainedProvider
+	for _, name := range config.LocationProviders {
+		provider, ok := newLocationProvider(strings.TrimSpace(name), config, sharedDeps)
+		if !ok {
+			logger.Error().Msgf("[Dependencies] Unknown location provider [%s], skipping", name)
+			continue
+		}
+
+		providers = append(providers, provider)
+	}
+
+	if len(providers) == 0 {
+		provider, _ := newLocationProvider("viacep", config, sharedDeps)
+		providers = append(providers, provider)
+	}
+
+	return location.NewLocationProviderChain(logger, providers...)
+}
+
+func newLocationProvider(name string, config *config.Conf, sharedDeps sharedDependencies) (location.ChainedProvider, bool) {
+	switch name {
+	case "viacep":
+		httpClient := newUpstreamHttpClient(
+			name,
+			config.ViaCepApiBaseUrl,
+			resolveRateLimitSettings(config.ViaCepRateLimitRPS, config.ViaCepRateLimitBurst, config.ViaCepRateLimitMode),
+			sharedDeps,
+			resolveHedgeOptions(config.ViaCepHedgeDelay, config.ViaCepHedgePercentile)...,
+		)
+
+		return location.ChainedProvider{
+			Provider: location.NewViaCepProvider(httpClient),
+			Timeout:  time.Duration(config.ViaCepTimeout) * time.Millisecond,
+		}, true
+	case "brasilapi":
+		httpClient := newUpstreamHttpClient(name, config.BrasilApiBaseUrl, httpclient.RateLimitSettings{}, sharedDeps)
+
+		return location.ChainedProvider{
+			Provider: location.NewBrasilApiProvider(httpClient),
+			Timeout:  time.Duration(config.BrasilApiTimeout) * time.Millisecond,
+		}, true
+	case "opencep":
+		httpClient := newUpstreamHttpClient(name, config.OpenCepBaseUrl, httpclient.RateLimitSettings{}, sharedDeps)
+
+		return location.ChainedProvider{
+			Provider: location.NewOpenCepProvider(httpClient),
+			Timeout:  time.Duration(config.OpenCepTimeout) * time.Millisecond,
+		}, true
+	default:
+		return location.ChainedProvider{}, false
+	}
+}
+
+// resolveWeatherProviders chains the providers listed in WEATHER_PROVIDERS, in order, skipping
+// unknown names, or queries them all at once when WEATHER_PROVIDERS_MODE is consensus. WeatherAPI
+// alone is used when none of them is known.
+func resolveWeatherProviders(config *config.Conf, geocoder climate.Geocoder, sharedDeps sharedDependencies) climate.WeatherProvider {
+	logger := sharedDeps.Logger.GetLogger()
+
+	var providers []climate.ChainedProvider
+	for _, name := range config.WeatherProviders {
+		provider, ok := newWeatherProvider(strings.TrimSpace(name), config, geocoder, sharedDeps)
+		if !ok {
+			logger.Error().Msgf("[Dependencies] Unknown weather provider [%s], skipping", name)
+			continue
+		}
+
+		providers = append(providers, provider)
+	}
+
+	if len(providers) == 0 {
+		provider, _ := newWeatherProvider("weatherapi", config, geocoder, sharedDeps)
+		providers = append(providers, provider)
+	}
+
+	if config.WeatherProvidersMode == "consensus" {
+		return climate.NewWeatherProviderConsensus(logger, config.WeatherConsensusMaxDeviation, providers...)
+	}
+
+	return climate.NewWeatherProviderChain(logger, providers...)
+}
+
+func newWeatherProvider(name string, config *config.Conf, geocoder climate.Geocoder, sharedDeps sharedDependencies) (climate.ChainedProvider, bool) {
+	switch name {
+	case "weatherapi":
+		httpClient := newUpstreamHttpClient(
+			name,
+			config.WeatherApiBaseUrl,
+			resolveRateLimitSettings(config.WeatherApiRateLimitRPS, config.WeatherApiRateLimitBurst, config.WeatherApiRateLimitMode),
+			sharedDeps,
+		)
+
+		return climate.ChainedProvider{
+			Provider: climate.NewWeatherApiProvider(httpClient, config.WeatherApiKey),
+			Timeout:  time.Duration(config.WeatherApiTimeout) * time.Millisecond,
+		}, true
+	case "openmeteo":
+		httpClient := newUpstreamHttpClient(name, config.OpenMeteoBaseUrl, httpclient.RateLimitSettings{}, sharedDeps)
+
+		return climate.ChainedProvider{
+			Provider: climate.NewOpenMeteoProvider(httpClient, geocoder),
+			Timeout:  time.Duration(config.OpenMeteoTimeout) * time.Millisecond,
+		}, true
+	default:
+		return climate.ChainedProvider{}, false
+	}
+}
+
+// resolveGeocoder returns the geocoder used to query the weather by coordinates, or nil to
+// search it by "city, UF" when GEOCODING_PROVIDER is empty.
+func resolveGeocoder(config *config.Conf, geocoder climate.Geocoder, logger zerolog.Logger) climate.Geocoder {
+	switch strings.TrimSpace(config.GeocodingProvider) {
+	case "":
+		return nil
+	case "openmeteo":
+		return geocoder
+	default:
+		logger.Error().Msgf("[Dependencies] Unknown geocoding provider [%s], searching weather by city name", config.GeocodingProvider)
+		return nil
+	}
+}
+
+// resolveLocationCacheBackend falls back to the in-memory cache when the file cannot be opened,
+// as losing the cache on restart is better than not starting at all.
+func resolveLocationCacheBackend(config *config.Conf, logger zerolog.Logger) (cache.Backend[location.CachedLocation], io.Closer) {
+	if config.LocationCacheBackend != "file" {
+		return cache.NewLRU[location.CachedLocation](config.LocationCacheMaxEntries), nil
+	}
+
+	store, err := cache.OpenFileStore[location.CachedLocation](config.LocationCacheFilePath, config.LocationCacheMaxEntries, logger)
+	if err != nil {
+		logger.Error().Msgf("[Dependencies] Error opening location cache file [%s], using memory: %s", config.LocationCacheFilePath, err)
+		return cache.NewLRU[location.CachedLocation](config.LocationCacheMaxEntries), nil
+	}
+
+	if config.LocationCacheCompactionInterval > 0 {
+		store.StartCompaction(time.Duration(config.LocationCacheCompactionInterval) * time.Millisecond)
+	}
+
+	return store, store
+}
@@ -0,0 +1,67 @@
+package httpclient
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"strings"
+)
+
+const DefaultMaxBodySize int64 = 1 << 20
+
+var (
+	ErrResponseTooLarge      = errors.New("response body too large")
+	ErrUnexpectedContentType = errors.New("unexpected content type")
+)
+
+func WithMaxBodySize(size int64) ClientOption {
+	return func(c *HttpClient) {
+		c.MaxBodySize = size
+	}
+}
+
+func (c HttpClient) maxBodySize() int64 {
+	if c.MaxBodySize > 0 {
+		return c.MaxBodySize
+	}
+
+	return DefaultMaxBodySize
+}
+
+// readBody reads at most the configured limit so a misbehaving upstream can not exhaust
+// memory, failing when the declared or actual body exceeds it.
+func (c HttpClient) readBody(resp *http.Response) ([]byte, error) {
+	limit := c.maxBodySize()
+
+	if resp.ContentLength > limit {
+		return nil, fmt.Errorf("%w: %d bytes declared, limit is %d", ErrResponseTooLarge, resp.ContentLength, limit)
+	}
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, limit+1))
+	if err != nil {
+		return nil, err
+	}
+
+	if int64(len(body)) > limit {
+		return nil, fmt.Errorf("%w: limit is %d bytes", ErrResponseTooLarge, limit)
+	}
+
+	return body, nil
+}
+
+func checkContentType(header http.Header) error {
+	contentType := header.Get("Content-Type")
+
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil || (mediaType != "application/json" && !strings.HasSuffix(mediaType, "+json")) {
+		return fmt.Errorf("%w %q, expected application/json", ErrUnexpectedContentType, contentType)
+	}
+
+	return nil
+}
+
+func decodeError(body []byte, err error) error {
+	return fmt.Errorf("error decoding response body %q: %w", snippet(body), err)
+}
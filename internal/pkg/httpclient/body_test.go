@@ -0,0 +1,94 @@
+package httpclient
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"go.opentelemetry.io/otel/trace/noop"
+)
+
+type BodyTestSuite struct {
+	suite.Suite
+}
+
+func TestBody(t *testing.T) {
+	suite.Run(t, new(BodyTestSuite))
+}
+
+func (s *BodyTestSuite) newClient(handler http.HandlerFunc, opts ...ClientOption) *HttpClient {
+	server := httptest.NewServer(handler)
+	s.T().Cleanup(server.Close)
+
+	return NewHttpClient("test-upstream", server.URL, time.Second, noop.NewTracerProvider().Tracer(""), opts...)
+}
+
+func (s *BodyTestSuite) TestBody() {
+	s.Run("should reject bodies larger than the limit without retrying", func() {
+		var calls atomic.Int32
+
+		client := s.newClient(func(w http.ResponseWriter, r *http.Request) {
+			calls.Add(1)
+			w.Header().Set("Content-Type", "application/json")
+			w.(http.Flusher).Flush()
+			w.Write([]byte(`{"city":"` + strings.Repeat("a", 2048) + `"}`))
+		}, WithMaxBodySize(1024), WithRetryPolicy(testRetryPolicy()))
+
+		var result response
+		err := client.Get(context.Background(), "/", &result)
+
+		s.Require().NotNil(err)
+		s.ErrorIs(err, ErrResponseTooLarge)
+		s.False(err.Retryable)
+		s.Equal(int32(1), calls.Load())
+	})
+
+	s.Run("should reject declared content length above the limit", func() {
+		client := s.newClient(func(w http.ResponseWriter, r *http.Request) {
+			writeJSON(w, `{"city":"`+strings.Repeat("a", 2048)+`"}`)
+		}, WithMaxBodySize(1024))
+
+		var result response
+		err := client.Get(context.Background(), "/", &result)
+
+		s.Require().NotNil(err)
+		s.ErrorIs(err, ErrResponseTooLarge)
+	})
+
+	s.Run("should reject non json content types", func() {
+		client := s.newClient(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "text/html")
+			w.Write([]byte(`<html>maintenance</html>`))
+		})
+
+		var result response
+		err := client.Get(context.Background(), "/", &result)
+
+		s.Require().NotNil(err)
+		s.ErrorIs(err, ErrUnexpectedContentType)
+		s.Equal("<html>maintenance</html>", err.Body)
+	})
+
+	s.Run("should include upstream and body preview on decode errors", func() {
+		client := s.newClient(func(w http.ResponseWriter, r *http.Request) {
+			writeJSON(w, `{"city":`+strings.Repeat("x", bodySnippetSize))
+		})
+
+		var result response
+		err := client.Get(context.Background(), "/", &result)
+
+		s.Require().NotNil(err)
+		s.Contains(err.Error(), "test-upstream")
+		s.Contains(err.Error(), `{\"city\":xxx`)
+		s.Contains(err.Error(), `..."`)
+
+		var clientErr *HttpClientError
+		s.True(errors.As(err, &clientErr))
+	})
+}
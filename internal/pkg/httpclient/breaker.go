@@ -0,0 +1,236 @@
+package httpclient
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/wellalencarweb/otel-lab-challenge/internal/pkg/customerrors"
+)
+
+var ErrCircuitOpen = errors.New("circuit breaker is open")
+
+type CircuitState int
+
+const (
+	CircuitClosed CircuitState = iota
+	CircuitOpen
+	CircuitHalfOpen
+)
+
+func (s CircuitState) String() string {
+	switch s {
+	case CircuitOpen:
+		return "open"
+	case CircuitHalfOpen:
+		return "half-open"
+	default:
+		return "closed"
+	}
+}
+
+type CircuitBreakerSettings struct {
+	FailureRatio float64
+	WindowSize   int
+	CoolDown     time.Duration
+}
+
+func DefaultCircuitBreakerSettings() CircuitBreakerSettings {
+	return CircuitBreakerSettings{
+		FailureRatio: 0.5,
+		WindowSize:   20,
+		CoolDown:     10 * time.Second,
+	}
+}
+
+type CircuitBreaker struct {
+	Name     string
+	Settings CircuitBreakerSettings
+	Logger   zerolog.Logger
+
+	mu       sync.Mutex
+	state    CircuitState
+	window   []bool
+	next     int
+	filled   int
+	failures int
+	openedAt time.Time
+	probing  bool
+	now      func() time.Time
+
+	transitions metric.Int64Counter
+	rejections  metric.Int64Counter
+}
+
+func NewCircuitBreaker(name string, settings CircuitBreakerSettings, logger zerolog.Logger) *CircuitBreaker {
+	meter := otel.Meter("httpclient")
+
+	transitions, _ := meter.Int64Counter(
+		"httpclient.circuit_breaker.transitions",
+		metric.WithDescription("Number of circuit breaker state transitions"),
+	)
+	rejections, _ := meter.Int64Counter(
+		"httpclient.circuit_breaker.rejections",
+		metric.WithDescription("Number of calls rejected by an open circuit breaker"),
+	)
+
+	return &CircuitBreaker{
+		Name:        name,
+		Settings:    settings,
+		Logger:      logger,
+		window:      make([]bool, max(settings.WindowSize, 1)),
+		now:         time.Now,
+		transitions: transitions,
+		rejections:  rejections,
+	}
+}
+
+func WithCircuitBreaker(breaker *CircuitBreaker) ClientOption {
+	return func(c *HttpClient) {
+		c.Breaker = breaker
+	}
+}
+
+func (b *CircuitBreaker) State() CircuitState {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return b.state
+}
+
+// Allow fails fast with a ServiceUnavailableError while the circuit is open, and lets a
+// single probe through once the cool-down has elapsed.
+func (b *CircuitBreaker) Allow(ctx context.Context) error {
+	if b == nil {
+		return nil
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.state == CircuitOpen {
+		if b.now().Sub(b.openedAt) < b.Settings.CoolDown {
+			return b.reject(ctx)
+		}
+
+		b.transition(ctx, CircuitHalfOpen)
+	}
+
+	if b.state == CircuitHalfOpen {
+		if b.probing {
+			return b.reject(ctx)
+		}
+
+		b.probing = true
+	}
+
+	return nil
+}
+
+// Done records the outcome of a call previously admitted by Allow. Transport errors and 5xx
+// responses count as failures; calls cancelled by the caller or held back by the client-side
+// rate limiter are not counted.
+func (b *CircuitBreaker) Done(ctx context.Context, resp *http.Response, err error) {
+	if b == nil {
+		return
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if errors.Is(err, context.Canceled) || errors.Is(err, ErrRateLimited) {
+		b.probing = false
+		return
+	}
+
+	failed := err != nil || resp.StatusCode >= http.StatusInternalServerError
+
+	switch b.state {
+	case CircuitHalfOpen:
+		b.probing = false
+
+		if failed {
+			b.trip(ctx)
+			return
+		}
+
+		b.resetWindow()
+		b.transition(ctx, CircuitClosed)
+	case CircuitClosed:
+		b.push(failed)
+
+		if b.filled == len(b.window) && float64(b.failures)/float64(b.filled) >= b.Settings.FailureRatio {
+			b.trip(ctx)
+		}
+	}
+}
+
+func (b *CircuitBreaker) push(failed bool) {
+	if b.filled == len(b.window) && b.window[b.next] {
+		b.failures--
+	}
+
+	b.window[b.next] = failed
+	b.next = (b.next + 1) % len(b.window)
+	b.filled = min(b.filled+1, len(b.window))
+
+	if failed {
+		b.failures++
+	}
+}
+
+func (b *CircuitBreaker) resetWindow() {
+	clear(b.window)
+	b.next = 0
+	b.filled = 0
+	b.failures = 0
+}
+
+func (b *CircuitBreaker) trip(ctx context.Context) {
+	b.openedAt = b.now()
+	b.resetWindow()
+	b.transition(ctx, CircuitOpen)
+}
+
+func (b *CircuitBreaker) transition(ctx context.Context, to CircuitState) {
+	from := b.state
+	b.state = to
+
+	attrs := []attribute.KeyValue{
+		attribute.String("upstream", b.Name),
+		attribute.String("from", from.String()),
+		attribute.String("to", to.String()),
+	}
+
+	b.Logger.Warn().Msgf("[CircuitBreaker] Upstream [%s] changed state from [%s] to [%s]", b.Name, from, to)
+	trace.SpanFromContext(ctx).AddEvent("circuit_breaker.state_change", trace.WithAttributes(attrs...))
+	b.transitions.Add(ctx, 1, metric.WithAttributes(attrs...))
+}
+
+func (b *CircuitBreaker) reject(ctx context.Context) error {
+	attrs := []attribute.KeyValue{
+		attribute.String("upstream", b.Name),
+		attribute.String("state", b.state.String()),
+	}
+
+	trace.SpanFromContext(ctx).AddEvent("circuit_breaker.rejected", trace.WithAttributes(attrs...))
+	b.rejections.Add(ctx, 1, metric.WithAttributes(attrs...))
+
+	return &customerrors.ServiceUnavailableError{
+		Err:     ErrCircuitOpen,
+		Message: fmt.Sprintf("%s is unavailable", b.Name),
+		Tags: map[string]interface{}{
+			"upstream": b.Name,
+			"state":    b.state.String(),
+		},
+	}
+}
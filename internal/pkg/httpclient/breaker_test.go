@@ -0,0 +1,109 @@
+package httpclient
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/wellalencarweb/otel-lab-challenge/internal/pkg/customerrors"
+)
+
+type CircuitBreakerTestSuite struct {
+	suite.Suite
+	Now     time.Time
+	Breaker *CircuitBreaker
+}
+
+func TestCircuitBreaker(t *testing.T) {
+	suite.Run(t, new(CircuitBreakerTestSuite))
+}
+
+func (s *CircuitBreakerTestSuite) SetupSubTest() {
+	s.Now = time.Now()
+	s.Breaker = NewCircuitBreaker("test-upstream", CircuitBreakerSettings{
+		FailureRatio: 0.5,
+		WindowSize:   4,
+		CoolDown:     time.Second,
+	}, zerolog.Nop())
+	s.Breaker.now = func() time.Time { return s.Now }
+}
+
+func (s *CircuitBreakerTestSuite) call(statusCode int) error {
+	ctx := context.Background()
+
+	if err := s.Breaker.Allow(ctx); err != nil {
+		return err
+	}
+
+	s.Breaker.Done(ctx, &http.Response{StatusCode: statusCode}, nil)
+
+	return nil
+}
+
+func (s *CircuitBreakerTestSuite) TestCircuitBreaker() {
+	s.Run("should stay closed while failure ratio is below threshold", func() {
+		s.NoError(s.call(http.StatusOK))
+		s.NoError(s.call(http.StatusOK))
+		s.NoError(s.call(http.StatusOK))
+		s.NoError(s.call(http.StatusInternalServerError))
+
+		s.Equal(CircuitClosed, s.Breaker.State())
+	})
+
+	s.Run("should open and fail fast when failure ratio is reached", func() {
+		s.NoError(s.call(http.StatusOK))
+		s.NoError(s.call(http.StatusOK))
+		s.NoError(s.call(http.StatusBadGateway))
+		s.NoError(s.call(http.StatusServiceUnavailable))
+
+		s.Equal(CircuitOpen, s.Breaker.State())
+
+		err := s.call(http.StatusOK)
+
+		var unavailableErr *customerrors.ServiceUnavailableError
+		s.True(errors.As(err, &unavailableErr))
+		s.ErrorIs(unavailableErr.Err, ErrCircuitOpen)
+	})
+
+	s.Run("should allow a single probe after cool-down and close on success", func() {
+		for i := 0; i < 4; i++ {
+			s.NoError(s.call(http.StatusInternalServerError))
+		}
+
+		s.Now = s.Now.Add(2 * time.Second)
+
+		ctx := context.Background()
+		s.NoError(s.Breaker.Allow(ctx))
+		s.Equal(CircuitHalfOpen, s.Breaker.State())
+		s.Error(s.Breaker.Allow(ctx))
+
+		s.Breaker.Done(ctx, &http.Response{StatusCode: http.StatusOK}, nil)
+		s.Equal(CircuitClosed, s.Breaker.State())
+	})
+
+	s.Run("should reopen when the probe fails", func() {
+		for i := 0; i < 4; i++ {
+			s.NoError(s.call(http.StatusInternalServerError))
+		}
+
+		s.Now = s.Now.Add(2 * time.Second)
+
+		s.NoError(s.call(http.StatusInternalServerError))
+		s.Equal(CircuitOpen, s.Breaker.State())
+		s.Error(s.call(http.StatusOK))
+	})
+
+	s.Run("should not count calls cancelled by the caller", func() {
+		for i := 0; i < 4; i++ {
+			s.NoError(s.Breaker.Allow(context.Background()))
+			s.Breaker.Done(context.Background(), nil, context.Canceled)
+		}
+
+		s.Equal(CircuitClosed, s.Breaker.State())
+	})
+}
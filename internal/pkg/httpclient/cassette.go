@@ -0,0 +1,227 @@
+package httpclient
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+var ErrInteractionNotFound = errors.New("no recorded interaction matches the request")
+
+type CassetteMode string
+
+const (
+	CassetteReplay CassetteMode = "replay"
+	CassetteRecord CassetteMode = "record"
+)
+
+type RecordedRequest struct {
+	Method string `json:"method"`
+	URL    string `json:"url"`
+	Body   string `json:"body,omitempty"`
+}
+
+// RecordedResponse keeps JSON bodies as-is so cassettes stay readable and editable by hand;
+// anything else goes to RawBody.
+type RecordedResponse struct {
+	StatusCode int             `json:"status_code"`
+	Header     http.Header     `json:"header,omitempty"`
+	Body       json.RawMessage `json:"body,omitempty"`
+	RawBody    string          `json:"raw_body,omitempty"`
+}
+
+type Interaction struct {
+	Request  RecordedRequest  `json:"request"`
+	Response RecordedResponse `json:"response"`
+}
+
+type Cassette struct {
+	Interactions []Interaction `json:"interactions"`
+}
+
+// Recorder is an http.RoundTripper that either records the interactions sent through Next
+// to a cassette file or replays a previously recorded cassette without touching the network.
+// Redacted query parameters (the WeatherAPI key) never reach the cassette, and requests are
+// matched on method, redacted URL and body, each recorded interaction being replayed once.
+type Recorder struct {
+	Path string
+	Mode CassetteMode
+	Next http.RoundTripper
+
+	mu       sync.Mutex
+	cassette Cassette
+	replayed []bool
+}
+
+func NewRecorder(path string, mode CassetteMode, next http.RoundTripper) (*Recorder, error) {
+	recorder := &Recorder{
+		Path: path,
+		Mode: mode,
+		Next: next,
+	}
+
+	switch mode {
+	case CassetteRecord:
+		if recorder.Next == nil {
+			recorder.Next = http.DefaultTransport
+		}
+	case CassetteReplay:
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+
+		if err := json.Unmarshal(content, &recorder.cassette); err != nil {
+			return nil, fmt.Errorf("error decoding cassette %s: %w", path, err)
+		}
+
+		recorder.replayed = make([]bool, len(recorder.cassette.Interactions))
+	default:
+		return nil, fmt.Errorf("unknown cassette mode %q", mode)
+	}
+
+	return recorder, nil
+}
+
+func (r *Recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	recorded, err := newRecordedRequest(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if r.Mode == CassetteReplay {
+		return r.replay(req, recorded)
+	}
+
+	resp, err := r.Next.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.cassette.Interactions = append(r.cassette.Interactions, Interaction{
+		Request:  recorded,
+		Response: newRecordedResponse(resp, body),
+	})
+
+	return resp, nil
+}
+
+// Save writes the recorded interactions to Path. It is a no-op when replaying.
+func (r *Recorder) Save() error {
+	if r.Mode != CassetteRecord {
+		return nil
+	}
+
+	r.mu.Lock()
+	content, err := json.MarshalIndent(r.cassette, "", "  ")
+	r.mu.Unlock()
+
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(r.Path), 0o755); err != nil {
+		return err
+	}
+
+	return os.WriteFile(r.Path, append(content, '\n'), 0o644)
+}
+
+func (r *Recorder) replay(req *http.Request, recorded RecordedRequest) (*http.Response, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for i, interaction := range r.cassette.Interactions {
+		if r.replayed[i] || interaction.Request != recorded {
+			continue
+		}
+
+		r.replayed[i] = true
+
+		body := []byte(interaction.Response.RawBody)
+		if len(interaction.Response.Body) > 0 {
+			body = interaction.Response.Body
+		}
+
+		header := interaction.Response.Header.Clone()
+		if header == nil {
+			header = http.Header{}
+		}
+
+		return &http.Response{
+			Status:        fmt.Sprintf("%d %s", interaction.Response.StatusCode, http.StatusText(interaction.Response.StatusCode)),
+			StatusCode:    interaction.Response.StatusCode,
+			Proto:         "HTTP/1.1",
+			ProtoMajor:    1,
+			ProtoMinor:    1,
+			Header:        header,
+			Body:          io.NopCloser(bytes.NewReader(body)),
+			ContentLength: int64(len(body)),
+			Request:       req,
+		}, nil
+	}
+
+	return nil, fmt.Errorf("%w: %s %s", ErrInteractionNotFound, recorded.Method, recorded.URL)
+}
+
+func newRecordedRequest(req *http.Request) (RecordedRequest, error) {
+	recorded := RecordedRequest{
+		Method: req.Method,
+		URL:    redactURL(req.URL),
+	}
+
+	if req.GetBody != nil {
+		body, err := req.GetBody()
+		if err != nil {
+			return recorded, err
+		}
+		defer body.Close()
+
+		content, err := io.ReadAll(body)
+		if err != nil {
+			return recorded, err
+		}
+
+		recorded.Body = string(content)
+	}
+
+	return recorded, nil
+}
+
+func newRecordedResponse(resp *http.Response, body []byte) RecordedResponse {
+	recorded := RecordedResponse{
+		StatusCode: resp.StatusCode,
+		Header:     http.Header{},
+	}
+
+	for _, name := range []string{"Content-Type", "Retry-After"} {
+		if value := resp.Header.Get(name); value != "" {
+			recorded.Header.Set(name, value)
+		}
+	}
+
+	if json.Valid(body) {
+		recorded.Body = body
+	} else {
+		recorded.RawBody = string(body)
+	}
+
+	return recorded
+}
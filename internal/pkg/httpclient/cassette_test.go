@@ -0,0 +1,102 @@
+package httpclient
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"go.opentelemetry.io/otel/trace/noop"
+)
+
+type CassetteTestSuite struct {
+	suite.Suite
+}
+
+func TestCassette(t *testing.T) {
+	suite.Run(t, new(CassetteTestSuite))
+}
+
+func (s *CassetteTestSuite) newClient(baseURL string, recorder *Recorder) *HttpClient {
+	return NewHttpClient("test-upstream", baseURL, time.Second, noop.NewTracerProvider().Tracer(""), WithTransport(recorder))
+}
+
+func (s *CassetteTestSuite) TestRecordAndReplay() {
+	var calls atomic.Int32
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+
+		if r.URL.Query().Get("q") == "Atlantis" {
+			w.WriteHeader(http.StatusBadRequest)
+			writeJSON(w, `{"error":{"code":1006,"message":"No matching location found."}}`)
+			return
+		}
+
+		writeJSON(w, `{"city":"Rio de Janeiro"}`)
+	}))
+	defer server.Close()
+
+	path := filepath.Join(s.T().TempDir(), "cassettes", "upstream.json")
+
+	recorder, err := NewRecorder(path, CassetteRecord, nil)
+	s.Require().NoError(err)
+
+	client := s.newClient(server.URL, recorder)
+
+	var result response
+	s.Nil(client.Get(context.Background(), "/v1/current.json", &result, WithQuery("key", "secret-key"), WithQuery("q", "Rio de Janeiro")))
+	s.NotNil(client.Get(context.Background(), "/v1/current.json", &result, WithQuery("key", "secret-key"), WithQuery("q", "Atlantis")))
+	s.Require().NoError(recorder.Save())
+
+	content, err := os.ReadFile(path)
+	s.Require().NoError(err)
+	s.NotContains(string(content), "secret-key")
+	s.Contains(string(content), "key=REDACTED")
+
+	server.Close()
+
+	replayer, err := NewRecorder(path, CassetteReplay, nil)
+	s.Require().NoError(err)
+
+	client = s.newClient(server.URL, replayer)
+
+	result = response{}
+	s.Nil(client.Get(context.Background(), "/v1/current.json", &result, WithQuery("key", "another-key"), WithQuery("q", "Rio de Janeiro")))
+	s.Equal("Rio de Janeiro", result.City)
+
+	clientErr := client.Get(context.Background(), "/v1/current.json", &result, WithQuery("key", "another-key"), WithQuery("q", "Atlantis"))
+	s.Require().NotNil(clientErr)
+	s.Equal(http.StatusBadRequest, clientErr.StatusCode)
+	s.Contains(clientErr.Body, "No matching location found.")
+
+	s.Equal(int32(2), calls.Load())
+}
+
+func (s *CassetteTestSuite) TestReplay() {
+	s.Run("should fail requests missing from the cassette", func() {
+		path := filepath.Join(s.T().TempDir(), "empty.json")
+		s.Require().NoError(os.WriteFile(path, []byte(`{"interactions":[]}`), 0o644))
+
+		replayer, err := NewRecorder(path, CassetteReplay, nil)
+		s.Require().NoError(err)
+
+		var result response
+		clientErr := s.newClient("http://upstream.test", replayer).Get(context.Background(), "/", &result)
+
+		s.Require().NotNil(clientErr)
+		s.True(errors.Is(clientErr, ErrInteractionNotFound))
+	})
+
+	s.Run("should fail when the cassette does not exist", func() {
+		_, err := NewRecorder(filepath.Join(s.T().TempDir(), "missing.json"), CassetteReplay, nil)
+
+		s.Error(err)
+	})
+}
@@ -0,0 +1,122 @@
+package httpclient
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"time"
+	"unicode/utf8"
+
+	"github.com/wellalencarweb/otel-lab-challenge/internal/pkg/customerrors"
+)
+
+const bodySnippetSize = 256
+
+// HttpClientError describes a failed upstream call. StatusCode is zero when no response
+// was received (transport failure, open circuit, invalid request).
+type HttpClientError struct {
+	Upstream   string
+	StatusCode int
+	Body       string
+	Retryable  bool
+	RetryAfter time.Duration
+	Err        error
+}
+
+func (e *HttpClientError) Error() string {
+	if e.StatusCode == 0 {
+		return fmt.Sprintf("%s: %v", e.Upstream, e.Err)
+	}
+
+	return fmt.Sprintf("%s: status %d: %v", e.Upstream, e.StatusCode, e.Err)
+}
+
+func (e *HttpClientError) Unwrap() error {
+	return e.Err
+}
+
+// AsCustomError maps the failure to the customerrors type handlers translate into a status:
+// 404 becomes NotFoundError, 429 or a local rate limit becomes TooManyRequestsError, an open
+// circuit or a transient failure becomes ServiceUnavailableError and anything else becomes
+// UnknownError.
+func (e *HttpClientError) AsCustomError(notFoundMessage string, message string, tags map[string]interface{}) error {
+	var unavailableErr *customerrors.ServiceUnavailableError
+	if errors.As(e.Err, &unavailableErr) {
+		return unavailableErr
+	}
+
+	var tooManyRequestsErr *customerrors.TooManyRequestsError
+	if errors.As(e.Err, &tooManyRequestsErr) {
+		return tooManyRequestsErr
+	}
+
+	switch {
+	case e.StatusCode == http.StatusNotFound:
+		return &customerrors.NotFoundError{
+			Err:     e,
+			Message: notFoundMessage,
+			Tags:    tags,
+		}
+	case e.StatusCode == http.StatusTooManyRequests:
+		return &customerrors.TooManyRequestsError{
+			Err:        e,
+			Message:    fmt.Sprintf("%s rate limit exceeded", e.Upstream),
+			RetryAfter: e.RetryAfter,
+			Tags:       tags,
+		}
+	case e.Retryable:
+		return &customerrors.ServiceUnavailableError{
+			Err:     e,
+			Message: fmt.Sprintf("%s is unavailable", e.Upstream),
+			Tags:    tags,
+		}
+	default:
+		return &customerrors.UnknownError{
+			Err:     e,
+			Message: message,
+			Tags:    tags,
+		}
+	}
+}
+
+func (c HttpClient) newError(err error, retryable bool) *HttpClientError {
+	return &HttpClientError{
+		Upstream:  c.Name,
+		Retryable: retryable,
+		Err:       err,
+	}
+}
+
+func (c HttpClient) newStatusError(resp *Response, err error) *HttpClientError {
+	retryableStatusCodes := c.RetryPolicy.RetryableStatusCodes
+	if len(retryableStatusCodes) == 0 {
+		retryableStatusCodes = DefaultRetryPolicy().RetryableStatusCodes
+	}
+
+	retryAfter, _ := parseRetryAfter(resp.Header.Get("Retry-After"))
+
+	return &HttpClientError{
+		Upstream:   c.Name,
+		StatusCode: resp.StatusCode,
+		Body:       snippet(resp.Body),
+		Retryable:  slices.Contains(retryableStatusCodes, resp.StatusCode),
+		RetryAfter: retryAfter,
+		Err:        err,
+	}
+}
+
+// snippet truncates a response body quoted in an error to bodySnippetSize bytes, on a rune
+// boundary.
+func snippet(body []byte) string {
+	if len(body) <= bodySnippetSize {
+		return string(body)
+	}
+
+	cut := bodySnippetSize
+	for cut > 0 && !utf8.RuneStart(body[cut]) {
+		cut--
+	}
+
+	return string(body[:cut]) + "..."
+}
@@ -0,0 +1,59 @@
+package httpclient
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/wellalencarweb/otel-lab-challenge/internal/pkg/customerrors"
+)
+
+type HttpClientErrorTestSuite struct {
+	suite.Suite
+}
+
+func TestHttpClientError(t *testing.T) {
+	suite.Run(t, new(HttpClientErrorTestSuite))
+}
+
+func (s *HttpClientErrorTestSuite) TestHttpClientError() {
+	s.Run("should describe status and transport failures", func() {
+		statusErr := &HttpClientError{Upstream: "viacep", StatusCode: http.StatusBadGateway, Err: errors.New("bad gateway")}
+		transportErr := &HttpClientError{Upstream: "viacep", Err: errors.New("connection refused")}
+
+		s.Equal("viacep: status 502: bad gateway", statusErr.Error())
+		s.Equal("viacep: connection refused", transportErr.Error())
+	})
+
+	s.Run("should unwrap the underlying error", func() {
+		cause := errors.New("any-error")
+		err := fmt.Errorf("wrapped: %w", &HttpClientError{Upstream: "viacep", Err: cause})
+
+		var clientErr *HttpClientError
+		s.True(errors.As(err, &clientErr))
+		s.ErrorIs(err, cause)
+	})
+
+	s.Run("should keep the open circuit error when mapping", func() {
+		unavailableErr := &customerrors.ServiceUnavailableError{Err: ErrCircuitOpen, Message: "viacep is unavailable"}
+		err := &HttpClientError{Upstream: "viacep", Err: unavailableErr}
+
+		s.Same(unavailableErr, err.AsCustomError("not found", "unknown", nil))
+	})
+
+	s.Run("should truncate the body snippet on a rune boundary", func() {
+		client := HttpClient{Name: "weatherapi"}
+		body := strings.Repeat("á", bodySnippetSize)
+
+		err := client.newStatusError(&Response{StatusCode: http.StatusServiceUnavailable, Body: []byte(body)}, errors.New("service unavailable"))
+
+		s.True(err.Retryable)
+		s.True(strings.HasSuffix(err.Body, "..."))
+		s.LessOrEqual(len(err.Body), bodySnippetSize+len("..."))
+		s.True(strings.HasPrefix(body, strings.TrimSuffix(err.Body, "...")))
+	})
+}
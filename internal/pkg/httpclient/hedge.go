@@ -0,0 +1,178 @@
+package httpclient
+
+import (
+	"context"
+	"net/http"
+	"slices"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	hedgeLaunchedKey = attribute.Key("http.hedge.launched")
+	hedgeWonKey      = attribute.Key("http.hedge.won")
+	hedgeDelayKey    = attribute.Key("http.hedge.delay_ms")
+)
+
+const (
+	hedgeLatencyWindow     = 100
+	hedgeMinLatencySamples = 20
+)
+
+type HedgePolicy struct {
+	Delay      time.Duration
+	Percentile float64
+}
+
+// Hedger fires a second identical request when the first one has not answered after the
+// hedge delay: the configured percentile of recent latencies once enough samples were
+// collected, or the fixed Delay until then. Without a fixed Delay, requests are not hedged
+// until the window has enough samples.
+type Hedger struct {
+	Policy HedgePolicy
+
+	mu        sync.Mutex
+	latencies []time.Duration
+	next      int
+}
+
+func NewHedger(policy HedgePolicy) *Hedger {
+	return &Hedger{
+		Policy:    policy,
+		latencies: make([]time.Duration, 0, hedgeLatencyWindow),
+	}
+}
+
+func WithHedging(hedger *Hedger) ClientOption {
+	return func(c *HttpClient) {
+		c.Hedger = hedger
+	}
+}
+
+// Delay reports the current hedge delay and whether requests should be hedged at all.
+func (h *Hedger) Delay() (time.Duration, bool) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if h.Policy.Percentile <= 0 || len(h.latencies) < hedgeMinLatencySamples {
+		return h.Policy.Delay, h.Policy.Delay > 0
+	}
+
+	sorted := slices.Clone(h.latencies)
+	slices.Sort(sorted)
+
+	index := int(h.Policy.Percentile*float64(len(sorted))+0.5) - 1
+
+	return sorted[min(max(index, 0), len(sorted)-1)], true
+}
+
+func (h *Hedger) observe(latency time.Duration) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if len(h.latencies) < hedgeLatencyWindow {
+		h.latencies = append(h.latencies, latency)
+		return
+	}
+
+	h.latencies[h.next] = latency
+	h.next = (h.next + 1) % hedgeLatencyWindow
+}
+
+type hedgeResult struct {
+	resp    *http.Response
+	body    []byte
+	err     error
+	hedge   bool
+	latency time.Duration
+}
+
+func (r hedgeResult) ok() bool {
+	return r.err == nil && r.resp.StatusCode < http.StatusInternalServerError
+}
+
+func (c HttpClient) hedgedAttempt(ctx context.Context, span trace.Span, req *http.Request) (*http.Response, []byte, error) {
+	if c.Hedger == nil || !slices.Contains(idempotentMethods, req.Method) {
+		return c.attempt(ctx, req)
+	}
+
+	delay, ok := c.Hedger.Delay()
+	if !ok {
+		start := time.Now()
+		resp, body, err := c.attempt(ctx, req)
+		if (hedgeResult{resp: resp, err: err}).ok() {
+			c.Hedger.observe(time.Since(start))
+		}
+
+		return resp, body, err
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	results := make(chan hedgeResult, 2)
+	launch := func(hedge bool) {
+		go func() {
+			if hedge {
+				if err := c.RateLimiter.Acquire(ctx); err != nil {
+					results <- hedgeResult{err: err, hedge: true}
+					return
+				}
+			}
+
+			start := time.Now()
+			resp, body, err := c.attempt(ctx, req)
+			results <- hedgeResult{resp: resp, body: body, err: err, hedge: hedge, latency: time.Since(start)}
+		}()
+	}
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	start := time.Now()
+	launch(false)
+	inflight := 1
+
+	var primary hedgeResult
+
+	for {
+		select {
+		case <-timer.C:
+			launch(true)
+			inflight++
+
+			span.SetAttributes(hedgeLaunchedKey.Bool(true), hedgeDelayKey.Int64(delay.Milliseconds()))
+		case result := <-results:
+			inflight--
+
+			if result.ok() {
+				// The latency of the first request is what the delay predicts: when the hedge
+				// wins, the time waited so far is a lower bound of it, which keeps the slow
+				// requests hedging avoids in the window.
+				if result.hedge {
+					c.Hedger.observe(time.Since(start))
+				} else {
+					c.Hedger.observe(result.latency)
+				}
+				span.SetAttributes(hedgeWonKey.Bool(result.hedge))
+
+				return result.resp, result.body, result.err
+			}
+
+			if !result.hedge {
+				primary = result
+			}
+
+			if inflight == 0 {
+				if primary.resp == nil && primary.err == nil {
+					primary = result
+				}
+
+				return primary.resp, primary.body, primary.err
+			}
+		}
+	}
+}
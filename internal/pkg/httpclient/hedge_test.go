@@ -0,0 +1,190 @@
+package httpclient
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"go.opentelemetry.io/otel/attribute"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+type HedgeTestSuite struct {
+	suite.Suite
+	SpanRecorder *tracetest.SpanRecorder
+}
+
+func TestHedge(t *testing.T) {
+	suite.Run(t, new(HedgeTestSuite))
+}
+
+func (s *HedgeTestSuite) SetupTest() {
+	s.SpanRecorder = tracetest.NewSpanRecorder()
+}
+
+func (s *HedgeTestSuite) newClient(hedger *Hedger, handler http.HandlerFunc) *HttpClient {
+	server := httptest.NewServer(handler)
+	s.T().Cleanup(server.Close)
+
+	tracer := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(s.SpanRecorder)).Tracer("hedge-test")
+
+	return NewHttpClient("test-upstream", server.URL, time.Second, tracer, WithRetryPolicy(testRetryPolicy()), WithHedging(hedger))
+}
+
+func (s *HedgeTestSuite) spanAttributes() map[attribute.Key]attribute.Value {
+	spans := s.SpanRecorder.Ended()
+	s.Require().NotEmpty(spans)
+
+	attrs := map[attribute.Key]attribute.Value{}
+	for _, attr := range spans[len(spans)-1].Attributes() {
+		attrs[attr.Key] = attr.Value
+	}
+
+	return attrs
+}
+
+func (s *HedgeTestSuite) TestHedge() {
+	s.Run("should not hedge requests answered before the delay", func() {
+		var calls atomic.Int32
+
+		client := s.newClient(NewHedger(HedgePolicy{Delay: time.Second}), func(w http.ResponseWriter, r *http.Request) {
+			calls.Add(1)
+			writeJSON(w, `{"city":"Rio de Janeiro"}`)
+		})
+
+		var result response
+		s.Nil(client.Get(context.Background(), "/", &result))
+		s.Equal("Rio de Janeiro", result.City)
+		s.Equal(int32(1), calls.Load())
+
+		attrs := s.spanAttributes()
+		s.NotContains(attrs, hedgeLaunchedKey)
+		s.False(attrs[hedgeWonKey].AsBool())
+	})
+
+	s.Run("should return the hedge when the first request is slow and cancel the loser", func() {
+		var calls atomic.Int32
+		cancelled := make(chan struct{})
+
+		client := s.newClient(NewHedger(HedgePolicy{Delay: 10 * time.Millisecond}), func(w http.ResponseWriter, r *http.Request) {
+			if calls.Add(1) == 1 {
+				select {
+				case <-r.Context().Done():
+					close(cancelled)
+				case <-time.After(time.Second):
+				}
+				return
+			}
+
+			writeJSON(w, `{"city":"Rio de Janeiro"}`)
+		})
+
+		start := time.Now()
+		var result response
+		s.Nil(client.Get(context.Background(), "/", &result))
+		s.Equal("Rio de Janeiro", result.City)
+		s.Less(time.Since(start), 500*time.Millisecond)
+
+		select {
+		case <-cancelled:
+		case <-time.After(500 * time.Millisecond):
+			s.Fail("slow request was not cancelled")
+		}
+
+		attrs := s.spanAttributes()
+		s.True(attrs[hedgeLaunchedKey].AsBool())
+		s.True(attrs[hedgeWonKey].AsBool())
+	})
+
+	s.Run("should wait for the hedge when the first request fails", func() {
+		var calls atomic.Int32
+
+		client := s.newClient(NewHedger(HedgePolicy{Delay: 10 * time.Millisecond}), func(w http.ResponseWriter, r *http.Request) {
+			if calls.Add(1) == 1 {
+				time.Sleep(50 * time.Millisecond)
+				w.WriteHeader(http.StatusBadGateway)
+				return
+			}
+
+			time.Sleep(100 * time.Millisecond)
+			writeJSON(w, `{"city":"Rio de Janeiro"}`)
+		})
+
+		var result response
+		s.Nil(client.Get(context.Background(), "/", &result))
+		s.Equal(int32(2), calls.Load())
+		s.True(s.spanAttributes()[hedgeWonKey].AsBool())
+	})
+
+	s.Run("should not hedge non idempotent requests", func() {
+		var calls atomic.Int32
+
+		client := s.newClient(NewHedger(HedgePolicy{Delay: time.Millisecond}), func(w http.ResponseWriter, r *http.Request) {
+			calls.Add(1)
+			time.Sleep(20 * time.Millisecond)
+			writeJSON(w, `{"city":"Rio de Janeiro"}`)
+		})
+
+		var result response
+		s.Nil(client.Post(context.Background(), "/", map[string]string{"zipcode": "22021001"}, &result))
+		s.Equal(int32(1), calls.Load())
+	})
+}
+
+func (s *HedgeTestSuite) TestDelay() {
+	hedger := NewHedger(HedgePolicy{Delay: 50 * time.Millisecond, Percentile: 0.9})
+
+	for i := 1; i < hedgeMinLatencySamples; i++ {
+		hedger.observe(time.Duration(i) * time.Millisecond)
+	}
+	delay, ok := hedger.Delay()
+	s.True(ok)
+	s.Equal(50*time.Millisecond, delay)
+
+	hedger.observe(hedgeMinLatencySamples * time.Millisecond)
+	delay, ok = hedger.Delay()
+	s.True(ok)
+	s.Equal(18*time.Millisecond, delay)
+
+	for i := 0; i < hedgeLatencyWindow; i++ {
+		hedger.observe(time.Millisecond)
+	}
+	delay, ok = hedger.Delay()
+	s.True(ok)
+	s.Equal(time.Millisecond, delay)
+}
+
+func (s *HedgeTestSuite) TestPercentileOnly() {
+	hedger := NewHedger(HedgePolicy{Percentile: 0.9})
+
+	_, ok := hedger.Delay()
+	s.False(ok)
+
+	var calls atomic.Int32
+
+	client := s.newClient(hedger, func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		time.Sleep(20 * time.Millisecond)
+		writeJSON(w, `{"city":"Rio de Janeiro"}`)
+	})
+
+	for i := 0; i < hedgeMinLatencySamples-1; i++ {
+		var result response
+		s.Nil(client.Get(context.Background(), "/", &result))
+	}
+
+	s.Equal(int32(hedgeMinLatencySamples-1), calls.Load())
+	s.NotContains(s.spanAttributes(), hedgeLaunchedKey)
+
+	var result response
+	s.Nil(client.Get(context.Background(), "/", &result))
+
+	delay, ok := hedger.Delay()
+	s.True(ok)
+	s.GreaterOrEqual(delay, 20*time.Millisecond)
+}
@@ -1,73 +1,210 @@
 package httpclient
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"net/http"
+	"strings"
 	"time"
+
+	"go.opentelemetry.io/otel/trace"
 )
 
 type HttpClientInterface interface {
-	Get(ctx context.Context, endpoint string, responseObj interface{}) *HttpClientError
+	Get(ctx context.Context, endpoint string, responseObj interface{}, opts ...RequestOption) *HttpClientError
+	Post(ctx context.Context, endpoint string, body interface{}, responseObj interface{}, opts ...RequestOption) *HttpClientError
+	Put(ctx context.Context, endpoint string, body interface{}, responseObj interface{}, opts ...RequestOption) *HttpClientError
+	Delete(ctx context.Context, endpoint string, responseObj interface{}, opts ...RequestOption) *HttpClientError
+	Do(ctx context.Context, method string, endpoint string, body interface{}, opts ...RequestOption) (*Response, *HttpClientError)
 }
 
-type HttpClientError struct {
-	Error      error
-	StatusCode *int
+type Response struct {
+	StatusCode int
+	Header     http.Header
+	Body       []byte
 }
 
 type HttpClient struct {
-	BaseURL string
-	Timeout time.Duration
+	Name        string
+	BaseURL     string
+	Timeout     time.Duration
+	Tracer      trace.Tracer
+	RetryPolicy RetryPolicy
+	Breaker     *CircuitBreaker
+	RateLimiter *RateLimiter
+	Hedger      *Hedger
+	Transport   http.RoundTripper
+	MaxBodySize int64
+
+	client *http.Client
 }
 
-func NewHttpClient(baseURL string, timeout time.Duration) *HttpClient {
-	return &HttpClient{
+type ClientOption func(*HttpClient)
+
+func NewHttpClient(name string, baseURL string, timeout time.Duration, tracer trace.Tracer, opts ...ClientOption) *HttpClient {
+	client := &HttpClient{
+		Name:    name,
 		BaseURL: baseURL,
 		Timeout: timeout,
+		Tracer:  tracer,
+	}
+
+	for _, opt := range opts {
+		opt(client)
+	}
+
+	if client.Transport == nil {
+		client.Transport = NewTransport(DefaultTransportSettings())
 	}
+
+	client.client = &http.Client{
+		Timeout:   timeout,
+		Transport: client.Transport,
+	}
+
+	return client
+}
+
+func WithRetryPolicy(policy RetryPolicy) ClientOption {
+	return func(c *HttpClient) {
+		c.RetryPolicy = policy
+	}
+}
+
+func (c HttpClient) Get(ctx context.Context, endpoint string, responseObj interface{}, opts ...RequestOption) *HttpClientError {
+	_, err := c.do(ctx, http.MethodGet, endpoint, nil, responseObj, false, opts)
+	return err
 }
 
-func (c HttpClient) Get(ctx context.Context, endpoint string, responseObj interface{}) *HttpClientError {
-	path := fmt.Sprintf("%s%s", c.BaseURL, endpoint)
-	req, err := http.NewRequestWithContext(ctx, "GET", path, nil)
+func (c HttpClient) Post(ctx context.Context, endpoint string, body interface{}, responseObj interface{}, opts ...RequestOption) *HttpClientError {
+	_, err := c.do(ctx, http.MethodPost, endpoint, body, responseObj, false, opts)
+	return err
+}
+
+func (c HttpClient) Put(ctx context.Context, endpoint string, body interface{}, responseObj interface{}, opts ...RequestOption) *HttpClientError {
+	_, err := c.do(ctx, http.MethodPut, endpoint, body, responseObj, false, opts)
+	return err
+}
+
+func (c HttpClient) Delete(ctx context.Context, endpoint string, responseObj interface{}, opts ...RequestOption) *HttpClientError {
+	_, err := c.do(ctx, http.MethodDelete, endpoint, nil, responseObj, false, opts)
+	return err
+}
+
+// Do sends the request and returns the raw response without decoding it or treating
+// non-2xx statuses as errors; only transport failures are reported.
+func (c HttpClient) Do(ctx context.Context, method string, endpoint string, body interface{}, opts ...RequestOption) (*Response, *HttpClientError) {
+	return c.do(ctx, method, endpoint, body, nil, true, opts)
+}
+
+func (c HttpClient) do(
+	ctx context.Context,
+	method string,
+	endpoint string,
+	body interface{},
+	responseObj interface{},
+	raw bool,
+	opts []RequestOption,
+) (*Response, *HttpClientError) {
+	if c.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, c.Timeout)
+		defer cancel()
+	}
+
+	options := NewRequestOptions(opts...)
+
+	req, err := c.newRequest(ctx, method, endpoint, body, options)
 	if err != nil {
-		return &HttpClientError{
-			Error: err,
-		}
+		return nil, c.newError(err, false)
+	}
+
+	ctx, span := c.startSpan(ctx, req, urlTemplate(endpoint))
+	defer span.End()
+
+	if err := c.Breaker.Allow(ctx); err != nil {
+		recordError(span, err, "circuit breaker open")
+
+		return nil, c.newError(err, false)
 	}
 
-	client := &http.Client{}
+	resp, respBody, err := c.send(ctx, span, req)
+	c.Breaker.Done(ctx, resp, err)
 
-	resp, err := client.Do(req)
 	if err != nil {
-		errResp := &HttpClientError{
-			Error: err,
-		}
+		recordError(span, err, "error calling upstream")
 
-		if resp != nil {
-			errResp.StatusCode = &resp.StatusCode
-		}
+		return nil, c.newError(err, isTransient(err))
+	}
+
+	recordResponse(span, resp, len(respBody))
 
-		return errResp
+	response := &Response{
+		StatusCode: resp.StatusCode,
+		Header:     resp.Header,
+		Body:       respBody,
 	}
 
-	if resp.StatusCode == http.StatusNotFound {
-		return &HttpClientError{
-			Error:      fmt.Errorf("not found"),
-			StatusCode: &resp.StatusCode,
-		}
+	if raw {
+		return response, nil
 	}
 
-	defer resp.Body.Close()
+	if resp.StatusCode >= http.StatusBadRequest {
+		return response, c.newStatusError(response, errors.New(strings.ToLower(http.StatusText(resp.StatusCode))))
+	}
 
-	if err := json.NewDecoder(resp.Body).Decode(&responseObj); err != nil {
-		return &HttpClientError{
-			Error:      err,
-			StatusCode: &resp.StatusCode,
+	if responseObj == nil || len(respBody) == 0 {
+		return response, nil
+	}
+
+	if err := checkContentType(resp.Header); err != nil {
+		recordError(span, err, "unexpected content type")
+
+		return response, c.newStatusError(response, err)
+	}
+
+	if err := json.Unmarshal(respBody, responseObj); err != nil {
+		recordError(span, err, "error decoding response body")
+
+		return response, c.newStatusError(response, decodeError(respBody, err))
+	}
+
+	return response, nil
+}
+
+func (c HttpClient) newRequest(ctx context.Context, method string, endpoint string, body interface{}, options RequestOptions) (*http.Request, error) {
+	path, err := options.url(c.BaseURL, endpoint)
+	if err != nil {
+		return nil, err
+	}
+
+	var reader io.Reader
+	if body != nil {
+		payload, err := json.Marshal(body)
+		if err != nil {
+			return nil, fmt.Errorf("error encoding request body: %w", err)
 		}
+
+		reader = bytes.NewReader(payload)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, path, reader)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("Accept", "application/json")
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	for key, values := range options.Headers {
+		req.Header[key] = values
 	}
 
-	return nil
+	return req, nil
 }
@@ -0,0 +1,169 @@
+package httpclient
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+type response struct {
+	City string `json:"city"`
+}
+
+type HttpClientTestSuite struct {
+	suite.Suite
+	SpanRecorder *tracetest.SpanRecorder
+	Tracer       trace.Tracer
+}
+
+func TestHttpClient(t *testing.T) {
+	suite.Run(t, new(HttpClientTestSuite))
+}
+
+func (s *HttpClientTestSuite) SetupTest() {
+	s.SpanRecorder = tracetest.NewSpanRecorder()
+	s.Tracer = sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(s.SpanRecorder)).Tracer("httpclient-test")
+
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+}
+
+func (s *HttpClientTestSuite) newServer(handler http.HandlerFunc) (*httptest.Server, *HttpClient) {
+	server := httptest.NewServer(handler)
+	s.T().Cleanup(server.Close)
+
+	return server, NewHttpClient("test-upstream", server.URL, time.Second, s.Tracer)
+}
+
+func (s *HttpClientTestSuite) TestGet() {
+	s.Run("should inject trace context and record a client span", func() {
+		var traceparent, path string
+
+		_, client := s.newServer(func(w http.ResponseWriter, r *http.Request) {
+			traceparent = r.Header.Get("traceparent")
+			path = r.URL.Path
+			writeJSON(w, `{"city":"Rio de Janeiro"}`)
+		})
+
+		ctx, parent := s.Tracer.Start(context.Background(), "parent")
+		var result response
+		err := client.Get(ctx, "/{zipcode}/json/?key=secret", &result, WithPathParam("zipcode", "22021001"))
+		parent.End()
+
+		s.Nil(err)
+		s.Equal("Rio de Janeiro", result.City)
+		s.Equal("/22021001/json/", path)
+
+		spans := s.SpanRecorder.Ended()
+		s.Require().Len(spans, 2)
+
+		clientSpan := spans[0]
+		s.Equal("GET /{zipcode}/json/", clientSpan.Name())
+		s.Equal(trace.SpanKindClient, clientSpan.SpanKind())
+		s.Equal(parent.SpanContext().SpanID(), clientSpan.Parent().SpanID())
+		s.Contains(traceparent, clientSpan.SpanContext().SpanID().String())
+
+		attrs := attribute.NewSet(clientSpan.Attributes()...)
+		template, _ := attrs.Value("url.template")
+		status, _ := attrs.Value("http.response.status_code")
+		fullURL, _ := attrs.Value("url.full")
+		s.Equal("/{zipcode}/json/", template.AsString())
+		s.Equal(int64(http.StatusOK), status.AsInt64())
+		s.NotContains(fullURL.AsString(), "secret")
+	})
+
+	s.Run("should return status code when upstream responds not found", func() {
+		_, client := s.newServer(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusNotFound)
+		})
+
+		var result response
+		err := client.Get(context.Background(), "/", &result)
+
+		s.Require().NotNil(err)
+		s.Equal(http.StatusNotFound, err.StatusCode)
+		s.Equal("test-upstream", err.Upstream)
+	})
+}
+
+func (s *HttpClientTestSuite) TestVerbs() {
+	s.Run("should send json body, headers and query params", func() {
+		var method, contentType, auth, query string
+		var payload map[string]string
+
+		_, client := s.newServer(func(w http.ResponseWriter, r *http.Request) {
+			method = r.Method
+			contentType = r.Header.Get("Content-Type")
+			auth = r.Header.Get("Authorization")
+			query = r.URL.RawQuery
+			json.NewDecoder(r.Body).Decode(&payload)
+			writeJSON(w, `{"city":"Rio de Janeiro"}`)
+		})
+
+		var result response
+		err := client.Post(
+			context.Background(),
+			"/locations",
+			map[string]string{"cep": "22021001"},
+			&result,
+			WithHeader("Authorization", "Bearer token"),
+			WithQuery("q", "São Paulo"),
+		)
+
+		s.Nil(err)
+		s.Equal(http.MethodPost, method)
+		s.Equal("application/json", contentType)
+		s.Equal("Bearer token", auth)
+		s.Equal("q=S%C3%A3o+Paulo", query)
+		s.Equal("22021001", payload["cep"])
+		s.Equal("Rio de Janeiro", result.City)
+	})
+
+	s.Run("should not retry non idempotent requests", func() {
+		var calls atomic.Int32
+
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			calls.Add(1)
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}))
+		defer server.Close()
+
+		client := NewHttpClient("test-upstream", server.URL, time.Second, s.Tracer, WithRetryPolicy(DefaultRetryPolicy()))
+
+		err := client.Post(context.Background(), "/", map[string]string{}, nil)
+
+		s.NotNil(err)
+		s.Equal(int32(1), calls.Load())
+	})
+
+	s.Run("should return raw response without treating status as error", func() {
+		_, client := s.newServer(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("X-Upstream", "test")
+			w.WriteHeader(http.StatusTeapot)
+			w.Write([]byte("short and stout"))
+		})
+
+		resp, err := client.Do(context.Background(), http.MethodDelete, "/pot", nil)
+
+		s.Nil(err)
+		s.Equal(http.StatusTeapot, resp.StatusCode)
+		s.Equal("test", resp.Header.Get("X-Upstream"))
+		s.Equal("short and stout", string(resp.Body))
+	})
+}
+
+func writeJSON(w http.ResponseWriter, body string) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.Write([]byte(body))
+}
@@ -0,0 +1,147 @@
+package httpclient
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/wellalencarweb/otel-lab-challenge/internal/pkg/customerrors"
+)
+
+var ErrRateLimited = errors.New("rate limit exceeded")
+
+type RateLimitMode string
+
+const (
+	RateLimitWait   RateLimitMode = "wait"
+	RateLimitReject RateLimitMode = "reject"
+)
+
+type RateLimitSettings struct {
+	Rate  float64
+	Burst int
+	Mode  RateLimitMode
+}
+
+// RateLimiter is a token bucket refilled at Rate tokens per second up to Burst tokens.
+// Every attempt sent to the upstream, retries included, consumes one token.
+type RateLimiter struct {
+	Name     string
+	Settings RateLimitSettings
+
+	mu     sync.Mutex
+	tokens float64
+	last   time.Time
+	now    func() time.Time
+}
+
+func NewRateLimiter(name string, settings RateLimitSettings) *RateLimiter {
+	settings.Burst = max(settings.Burst, 1)
+
+	return &RateLimiter{
+		Name:     name,
+		Settings: settings,
+		tokens:   float64(settings.Burst),
+		last:     time.Now(),
+		now:      time.Now,
+	}
+}
+
+func WithRateLimiter(limiter *RateLimiter) ClientOption {
+	return func(c *HttpClient) {
+		c.RateLimiter = limiter
+	}
+}
+
+// Acquire takes a token, either waiting for one to be available or failing with a
+// TooManyRequestsError, depending on the configured mode. A limiter with no rate is a no-op.
+func (l *RateLimiter) Acquire(ctx context.Context) error {
+	if l == nil || l.Settings.Rate <= 0 {
+		return nil
+	}
+
+	span := trace.SpanFromContext(ctx)
+
+	l.mu.Lock()
+	l.refill()
+
+	if l.Settings.Mode == RateLimitReject && l.tokens < 1 {
+		retryAfter := l.delayFor(1 - l.tokens)
+		l.mu.Unlock()
+
+		return l.reject(span, retryAfter)
+	}
+
+	l.tokens--
+	delay := l.delayFor(-l.tokens)
+	l.mu.Unlock()
+
+	if delay <= 0 {
+		return nil
+	}
+
+	if deadline, ok := ctx.Deadline(); ok && time.Until(deadline) < delay {
+		l.release()
+		return l.reject(span, delay)
+	}
+
+	span.AddEvent("rate_limiter.wait", trace.WithAttributes(
+		attribute.String("upstream", l.Name),
+		attribute.Int64("wait_ms", delay.Milliseconds()),
+	))
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		l.release()
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+func (l *RateLimiter) refill() {
+	now := l.now()
+	elapsed := now.Sub(l.last).Seconds()
+	l.last = now
+
+	l.tokens = min(float64(l.Settings.Burst), l.tokens+elapsed*l.Settings.Rate)
+}
+
+func (l *RateLimiter) delayFor(tokens float64) time.Duration {
+	if tokens <= 0 {
+		return 0
+	}
+
+	return time.Duration(tokens / l.Settings.Rate * float64(time.Second))
+}
+
+func (l *RateLimiter) release() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.tokens = min(float64(l.Settings.Burst), l.tokens+1)
+}
+
+func (l *RateLimiter) reject(span trace.Span, retryAfter time.Duration) error {
+	span.AddEvent("rate_limiter.rejected", trace.WithAttributes(
+		attribute.String("upstream", l.Name),
+		attribute.Int64("retry_after_ms", retryAfter.Milliseconds()),
+	))
+
+	return &customerrors.TooManyRequestsError{
+		Err:        ErrRateLimited,
+		Message:    fmt.Sprintf("%s rate limit exceeded", l.Name),
+		RetryAfter: retryAfter,
+		Tags: map[string]interface{}{
+			"upstream": l.Name,
+		},
+	}
+}
@@ -0,0 +1,116 @@
+package httpclient
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/suite"
+	"go.opentelemetry.io/otel/trace/noop"
+
+	"github.com/wellalencarweb/otel-lab-challenge/internal/pkg/customerrors"
+)
+
+type RateLimiterTestSuite struct {
+	suite.Suite
+	Now time.Time
+}
+
+func TestRateLimiter(t *testing.T) {
+	suite.Run(t, new(RateLimiterTestSuite))
+}
+
+func (s *RateLimiterTestSuite) newLimiter(settings RateLimitSettings) *RateLimiter {
+	s.Now = time.Now()
+
+	limiter := NewRateLimiter("test-upstream", settings)
+	limiter.now = func() time.Time { return s.Now }
+	limiter.last = s.Now
+
+	return limiter
+}
+
+func (s *RateLimiterTestSuite) TestRateLimiter() {
+	s.Run("should reject once the burst is consumed", func() {
+		limiter := s.newLimiter(RateLimitSettings{Rate: 2, Burst: 2, Mode: RateLimitReject})
+		ctx := context.Background()
+
+		s.NoError(limiter.Acquire(ctx))
+		s.NoError(limiter.Acquire(ctx))
+
+		err := limiter.Acquire(ctx)
+
+		var tooManyRequestsErr *customerrors.TooManyRequestsError
+		s.Require().True(errors.As(err, &tooManyRequestsErr))
+		s.ErrorIs(tooManyRequestsErr.Err, ErrRateLimited)
+		s.Equal(500*time.Millisecond, tooManyRequestsErr.RetryAfter)
+
+		s.Now = s.Now.Add(500 * time.Millisecond)
+		s.NoError(limiter.Acquire(ctx))
+	})
+
+	s.Run("should wait for a token in wait mode", func() {
+		limiter := NewRateLimiter("test-upstream", RateLimitSettings{Rate: 20, Burst: 1, Mode: RateLimitWait})
+		ctx := context.Background()
+
+		s.NoError(limiter.Acquire(ctx))
+
+		start := time.Now()
+		s.NoError(limiter.Acquire(ctx))
+		s.GreaterOrEqual(time.Since(start), 40*time.Millisecond)
+	})
+
+	s.Run("should reject in wait mode when the wait exceeds the deadline", func() {
+		limiter := s.newLimiter(RateLimitSettings{Rate: 1, Burst: 1, Mode: RateLimitWait})
+
+		s.NoError(limiter.Acquire(context.Background()))
+
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+
+		var tooManyRequestsErr *customerrors.TooManyRequestsError
+		s.True(errors.As(limiter.Acquire(ctx), &tooManyRequestsErr))
+		s.InDelta(0, limiter.tokens, 0.001)
+	})
+
+	s.Run("should be a no-op without a rate", func() {
+		var limiter *RateLimiter
+		s.NoError(limiter.Acquire(context.Background()))
+		s.NoError(NewRateLimiter("test-upstream", RateLimitSettings{}).Acquire(context.Background()))
+	})
+
+	s.Run("should not send requests nor trip the breaker when rejected", func() {
+		var calls atomic.Int32
+
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			calls.Add(1)
+			writeJSON(w, `{"city":"Rio de Janeiro"}`)
+		}))
+		defer server.Close()
+
+		breaker := NewCircuitBreaker("test-upstream", CircuitBreakerSettings{FailureRatio: 0.5, WindowSize: 1, CoolDown: time.Minute}, zerolog.Nop())
+		client := NewHttpClient(
+			"test-upstream",
+			server.URL,
+			time.Second,
+			noop.NewTracerProvider().Tracer(""),
+			WithCircuitBreaker(breaker),
+			WithRateLimiter(NewRateLimiter("test-upstream", RateLimitSettings{Rate: 0.001, Burst: 1, Mode: RateLimitReject})),
+		)
+
+		var result response
+		s.Nil(client.Get(context.Background(), "/", &result))
+
+		err := client.Get(context.Background(), "/", &result)
+		s.Require().NotNil(err)
+		s.False(err.Retryable)
+		s.IsType(&customerrors.TooManyRequestsError{}, err.AsCustomError("not found", "unknown", nil))
+		s.Equal(int32(1), calls.Load())
+		s.Equal(CircuitClosed, breaker.State())
+	})
+}
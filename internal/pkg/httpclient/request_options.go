@@ -0,0 +1,92 @@
+package httpclient
+
+import (
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+type RequestOptions struct {
+	PathParams map[string]string
+	Query      url.Values
+	Headers    http.Header
+}
+
+type RequestOption func(*RequestOptions)
+
+func NewRequestOptions(opts ...RequestOption) RequestOptions {
+	options := RequestOptions{
+		PathParams: map[string]string{},
+		Query:      url.Values{},
+		Headers:    http.Header{},
+	}
+
+	for _, opt := range opts {
+		opt(&options)
+	}
+
+	return options
+}
+
+// WithPathParam replaces the "{name}" placeholder of the endpoint with the escaped value,
+// keeping the raw endpoint as the low-cardinality URL template reported on the client span.
+func WithPathParam(name string, value string) RequestOption {
+	return func(o *RequestOptions) {
+		o.PathParams[name] = value
+	}
+}
+
+func WithQuery(name string, value string) RequestOption {
+	return func(o *RequestOptions) {
+		o.Query.Add(name, value)
+	}
+}
+
+func WithQueryParams(params url.Values) RequestOption {
+	return func(o *RequestOptions) {
+		for name, values := range params {
+			for _, value := range values {
+				o.Query.Add(name, value)
+			}
+		}
+	}
+}
+
+func WithHeader(name string, value string) RequestOption {
+	return func(o *RequestOptions) {
+		o.Headers.Add(name, value)
+	}
+}
+
+func (o RequestOptions) expand(endpoint string) string {
+	for name, value := range o.PathParams {
+		endpoint = strings.ReplaceAll(endpoint, "{"+name+"}", url.PathEscape(value))
+	}
+
+	return endpoint
+}
+
+func (o RequestOptions) url(baseURL string, endpoint string) (string, error) {
+	u, err := url.Parse(baseURL + o.expand(endpoint))
+	if err != nil {
+		return "", err
+	}
+
+	if len(o.Query) > 0 {
+		query := u.Query()
+		for name, values := range o.Query {
+			for _, value := range values {
+				query.Add(name, value)
+			}
+		}
+
+		u.RawQuery = query.Encode()
+	}
+
+	return u.String(), nil
+}
+
+func urlTemplate(endpoint string) string {
+	template, _, _ := strings.Cut(endpoint, "?")
+	return template
+}
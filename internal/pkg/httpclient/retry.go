@@ -0,0 +1,187 @@
+package httpclient
+
+import (
+	"context"
+	"errors"
+	"math"
+	"math/rand"
+	"net/http"
+	"slices"
+	"strconv"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	semconv "go.opentelemetry.io/otel/semconv/v1.21.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const resendCountKey = attribute.Key("http.request.resend_count")
+
+var idempotentMethods = []string{
+	http.MethodGet,
+	http.MethodHead,
+	http.MethodOptions,
+	http.MethodPut,
+	http.MethodDelete,
+}
+
+type RetryPolicy struct {
+	MaxAttempts          int
+	BaseDelay            time.Duration
+	MaxDelay             time.Duration
+	Jitter               float64
+	RetryableStatusCodes []int
+}
+
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxAttempts: 3,
+		BaseDelay:   100 * time.Millisecond,
+		MaxDelay:    2 * time.Second,
+		Jitter:      0.2,
+		RetryableStatusCodes: []int{
+			http.StatusTooManyRequests,
+			http.StatusBadGateway,
+			http.StatusServiceUnavailable,
+			http.StatusGatewayTimeout,
+		},
+	}
+}
+
+// Backoff returns the exponential delay before the given retry (1-based), capped at MaxDelay
+// and spread by ±Jitter so concurrent callers do not retry in lockstep.
+func (p RetryPolicy) Backoff(retry int) time.Duration {
+	delay := float64(p.BaseDelay) * math.Pow(2, float64(retry-1))
+	if p.MaxDelay > 0 && delay > float64(p.MaxDelay) {
+		delay = float64(p.MaxDelay)
+	}
+
+	if p.Jitter > 0 {
+		delay += delay * p.Jitter * (rand.Float64()*2 - 1)
+	}
+
+	return time.Duration(delay)
+}
+
+func (p RetryPolicy) shouldRetry(req *http.Request, resp *http.Response, err error) bool {
+	if !slices.Contains(idempotentMethods, req.Method) {
+		return false
+	}
+
+	if err != nil {
+		return isTransient(err) && !errors.Is(err, context.DeadlineExceeded)
+	}
+
+	return slices.Contains(p.RetryableStatusCodes, resp.StatusCode)
+}
+
+func (p RetryPolicy) delay(retry int, resp *http.Response) time.Duration {
+	delay := p.Backoff(retry)
+
+	if resp != nil {
+		if retryAfter, ok := parseRetryAfter(resp.Header.Get("Retry-After")); ok && retryAfter > delay {
+			return retryAfter
+		}
+	}
+
+	return delay
+}
+
+func parseRetryAfter(value string) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+
+	if seconds, err := strconv.Atoi(value); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second, true
+	}
+
+	if date, err := http.ParseTime(value); err == nil {
+		return max(time.Until(date), 0), true
+	}
+
+	return 0, false
+}
+
+func (c HttpClient) send(ctx context.Context, span trace.Span, req *http.Request) (*http.Response, []byte, error) {
+	for attempt := 1; ; attempt++ {
+		if err := c.RateLimiter.Acquire(ctx); err != nil {
+			return nil, nil, err
+		}
+
+		resp, body, err := c.hedgedAttempt(ctx, span, req)
+
+		recordAttempt(span, attempt, resp, err)
+
+		if attempt >= c.RetryPolicy.MaxAttempts || !c.RetryPolicy.shouldRetry(req, resp, err) {
+			return resp, body, err
+		}
+
+		delay := c.RetryPolicy.delay(attempt, resp)
+		if deadline, ok := ctx.Deadline(); ok && time.Until(deadline) < delay {
+			return resp, body, err
+		}
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return resp, body, err
+		case <-timer.C:
+		}
+
+		span.SetAttributes(resendCountKey.Int(attempt))
+	}
+}
+
+func (c HttpClient) attempt(ctx context.Context, req *http.Request) (*http.Response, []byte, error) {
+	attemptReq := req.Clone(ctx)
+	if req.GetBody != nil {
+		body, err := req.GetBody()
+		if err != nil {
+			return nil, nil, err
+		}
+
+		attemptReq.Body = body
+	}
+
+	resp, err := c.httpClient().Do(attemptReq)
+	if err != nil {
+		return resp, nil, err
+	}
+
+	defer resp.Body.Close()
+
+	body, err := c.readBody(resp)
+	if err != nil {
+		return resp, nil, err
+	}
+
+	return resp, body, nil
+}
+
+func isTransient(err error) bool {
+	for _, permanent := range []error{context.Canceled, ErrResponseTooLarge, ErrRateLimited, ErrInteractionNotFound} {
+		if errors.Is(err, permanent) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func recordAttempt(span trace.Span, attempt int, resp *http.Response, err error) {
+	attrs := []attribute.KeyValue{
+		attribute.Int("http.attempt", attempt),
+	}
+
+	if resp != nil {
+		attrs = append(attrs, semconv.HTTPResponseStatusCode(resp.StatusCode))
+	}
+
+	if err != nil {
+		attrs = append(attrs, attribute.String("error.message", err.Error()))
+	}
+
+	span.AddEvent("http.attempt", trace.WithAttributes(attrs...))
+}
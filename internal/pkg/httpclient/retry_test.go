@@ -0,0 +1,173 @@
+package httpclient
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+type RetryTestSuite struct {
+	suite.Suite
+	SpanRecorder *tracetest.SpanRecorder
+}
+
+func TestRetry(t *testing.T) {
+	suite.Run(t, new(RetryTestSuite))
+}
+
+func (s *RetryTestSuite) SetupTest() {
+	s.SpanRecorder = tracetest.NewSpanRecorder()
+}
+
+func (s *RetryTestSuite) newClient(policy RetryPolicy, handler http.HandlerFunc) *HttpClient {
+	server := httptest.NewServer(handler)
+	s.T().Cleanup(server.Close)
+
+	tracer := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(s.SpanRecorder)).Tracer("retry-test")
+
+	return NewHttpClient("test-upstream", server.URL, time.Second, tracer, WithRetryPolicy(policy))
+}
+
+func testRetryPolicy() RetryPolicy {
+	policy := DefaultRetryPolicy()
+	policy.BaseDelay = time.Millisecond
+	policy.MaxDelay = 5 * time.Millisecond
+
+	return policy
+}
+
+func (s *RetryTestSuite) TestRetry() {
+	s.Run("should retry retryable status codes until success", func() {
+		var calls atomic.Int32
+
+		client := s.newClient(testRetryPolicy(), func(w http.ResponseWriter, r *http.Request) {
+			if calls.Add(1) < 3 {
+				w.WriteHeader(http.StatusServiceUnavailable)
+				return
+			}
+
+			writeJSON(w, `{"city":"Rio de Janeiro"}`)
+		})
+
+		var result response
+		err := client.Get(context.Background(), "/", &result)
+
+		s.Nil(err)
+		s.Equal(int32(3), calls.Load())
+		s.Equal("Rio de Janeiro", result.City)
+
+		spans := s.SpanRecorder.Ended()
+		s.Require().NotEmpty(spans)
+		s.Len(spans[len(spans)-1].Events(), 3)
+	})
+
+	s.Run("should not retry non retryable status codes", func() {
+		var calls atomic.Int32
+
+		client := s.newClient(testRetryPolicy(), func(w http.ResponseWriter, r *http.Request) {
+			calls.Add(1)
+			w.WriteHeader(http.StatusNotFound)
+		})
+
+		var result response
+		err := client.Get(context.Background(), "/", &result)
+
+		s.NotNil(err)
+		s.Equal(int32(1), calls.Load())
+	})
+
+	s.Run("should give up after max attempts", func() {
+		var calls atomic.Int32
+
+		client := s.newClient(testRetryPolicy(), func(w http.ResponseWriter, r *http.Request) {
+			calls.Add(1)
+			w.WriteHeader(http.StatusBadGateway)
+		})
+
+		var result response
+		err := client.Get(context.Background(), "/", &result)
+
+		s.NotNil(err)
+		s.Equal(int32(3), calls.Load())
+	})
+
+	s.Run("should not wait past the context deadline", func() {
+		var calls atomic.Int32
+
+		client := s.newClient(testRetryPolicy(), func(w http.ResponseWriter, r *http.Request) {
+			calls.Add(1)
+			w.Header().Set("Retry-After", "10")
+			w.WriteHeader(http.StatusTooManyRequests)
+		})
+
+		ctx, cancel := context.WithTimeout(context.Background(), 500*time.Millisecond)
+		defer cancel()
+
+		var result response
+		err := client.Get(ctx, "/", &result)
+
+		s.NotNil(err)
+		s.Equal(int32(1), calls.Load())
+	})
+
+	s.Run("should bound every attempt by the client timeout", func() {
+		var calls atomic.Int32
+
+		client := s.newClient(testRetryPolicy(), func(w http.ResponseWriter, r *http.Request) {
+			calls.Add(1)
+
+			select {
+			case <-r.Context().Done():
+			case <-time.After(80 * time.Millisecond):
+				w.WriteHeader(http.StatusBadGateway)
+			}
+		})
+		client.Timeout = 100 * time.Millisecond
+
+		start := time.Now()
+		var result response
+		err := client.Get(context.Background(), "/", &result)
+
+		s.NotNil(err)
+		s.Less(time.Since(start), 150*time.Millisecond)
+		s.LessOrEqual(calls.Load(), int32(2))
+	})
+}
+
+func (s *RetryTestSuite) TestBackoff() {
+	policy := RetryPolicy{
+		BaseDelay: 100 * time.Millisecond,
+		MaxDelay:  time.Second,
+	}
+
+	s.Equal(100*time.Millisecond, policy.Backoff(1))
+	s.Equal(400*time.Millisecond, policy.Backoff(3))
+	s.Equal(time.Second, policy.Backoff(10))
+
+	policy.Jitter = 0.5
+	for i := 0; i < 10; i++ {
+		delay := policy.Backoff(2)
+		s.GreaterOrEqual(delay, 100*time.Millisecond)
+		s.LessOrEqual(delay, 300*time.Millisecond)
+	}
+}
+
+func (s *RetryTestSuite) TestParseRetryAfter() {
+	delay, ok := parseRetryAfter("2")
+	s.True(ok)
+	s.Equal(2*time.Second, delay)
+
+	delay, ok = parseRetryAfter(time.Now().Add(time.Hour).UTC().Format(http.TimeFormat))
+	s.True(ok)
+	s.Greater(delay, 59*time.Minute)
+
+	_, ok = parseRetryAfter("soon")
+	s.False(ok)
+}
@@ -0,0 +1,77 @@
+package httpclient
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	semconv "go.opentelemetry.io/otel/semconv/v1.21.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const urlTemplateKey = attribute.Key("url.template")
+
+var redactedQueryParams = []string{"key"}
+
+func (c HttpClient) startSpan(ctx context.Context, req *http.Request, template string) (context.Context, trace.Span) {
+	ctx, span := c.tracer().Start(
+		ctx,
+		fmt.Sprintf("%s %s", req.Method, template),
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			semconv.HTTPRequestMethodKey.String(req.Method),
+			semconv.URLFull(redactURL(req.URL)),
+			semconv.ServerAddress(req.URL.Hostname()),
+			urlTemplateKey.String(template),
+			semconv.PeerService(c.Name),
+		),
+	)
+
+	otel.GetTextMapPropagator().Inject(ctx, propagation.HeaderCarrier(req.Header))
+
+	return ctx, span
+}
+
+func (c HttpClient) tracer() trace.Tracer {
+	if c.Tracer != nil {
+		return c.Tracer
+	}
+
+	return otel.Tracer("httpclient")
+}
+
+func recordResponse(span trace.Span, resp *http.Response, bodySize int) {
+	span.SetAttributes(
+		semconv.HTTPResponseStatusCode(resp.StatusCode),
+		semconv.HTTPResponseBodySize(bodySize),
+	)
+
+	if resp.StatusCode >= http.StatusBadRequest {
+		span.SetStatus(codes.Error, http.StatusText(resp.StatusCode))
+	}
+}
+
+func recordError(span trace.Span, err error, description string) {
+	span.SetStatus(codes.Error, description)
+	span.RecordError(err)
+}
+
+func redactURL(u *url.URL) string {
+	redacted := *u
+	query := redacted.Query()
+
+	for _, param := range redactedQueryParams {
+		if query.Has(param) {
+			query.Set(param, "REDACTED")
+		}
+	}
+
+	redacted.RawQuery = query.Encode()
+
+	return redacted.String()
+}
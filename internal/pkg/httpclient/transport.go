@@ -0,0 +1,69 @@
+package httpclient
+
+import (
+	"net"
+	"net/http"
+	"time"
+)
+
+type TransportSettings struct {
+	DialTimeout           time.Duration
+	MaxIdleConns          int
+	MaxIdleConnsPerHost   int
+	MaxConnsPerHost       int
+	IdleConnTimeout       time.Duration
+	TLSHandshakeTimeout   time.Duration
+	ResponseHeaderTimeout time.Duration
+	ForceAttemptHTTP2     bool
+}
+
+func DefaultTransportSettings() TransportSettings {
+	return TransportSettings{
+		DialTimeout:           5 * time.Second,
+		MaxIdleConns:          100,
+		MaxIdleConnsPerHost:   20,
+		IdleConnTimeout:       90 * time.Second,
+		TLSHandshakeTimeout:   5 * time.Second,
+		ResponseHeaderTimeout: 5 * time.Second,
+		ForceAttemptHTTP2:     true,
+	}
+}
+
+// NewTransport builds a pooled transport meant to be created once and shared by every
+// upstream client, so connections are kept alive between requests.
+func NewTransport(settings TransportSettings) *http.Transport {
+	dialer := &net.Dialer{
+		Timeout:   settings.DialTimeout,
+		KeepAlive: 30 * time.Second,
+	}
+
+	return &http.Transport{
+		Proxy:                 http.ProxyFromEnvironment,
+		DialContext:           dialer.DialContext,
+		MaxIdleConns:          settings.MaxIdleConns,
+		MaxIdleConnsPerHost:   settings.MaxIdleConnsPerHost,
+		MaxConnsPerHost:       settings.MaxConnsPerHost,
+		IdleConnTimeout:       settings.IdleConnTimeout,
+		TLSHandshakeTimeout:   settings.TLSHandshakeTimeout,
+		ResponseHeaderTimeout: settings.ResponseHeaderTimeout,
+		ExpectContinueTimeout: time.Second,
+		ForceAttemptHTTP2:     settings.ForceAttemptHTTP2,
+	}
+}
+
+func WithTransport(transport http.RoundTripper) ClientOption {
+	return func(c *HttpClient) {
+		c.Transport = transport
+	}
+}
+
+func (c HttpClient) httpClient() *http.Client {
+	if c.client != nil {
+		return c.client
+	}
+
+	return &http.Client{
+		Timeout:   c.Timeout,
+		Transport: c.Transport,
+	}
+}
@@ -0,0 +1,72 @@
+package httpclient
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"go.opentelemetry.io/otel/trace/noop"
+)
+
+type TransportTestSuite struct {
+	suite.Suite
+}
+
+func TestTransport(t *testing.T) {
+	suite.Run(t, new(TransportTestSuite))
+}
+
+func (s *TransportTestSuite) TestTransport() {
+	s.Run("should reuse connections across requests", func() {
+		var connections atomic.Int32
+
+		server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			writeJSON(w, `{"city":"Rio de Janeiro"}`)
+		}))
+		server.Config.ConnState = func(conn net.Conn, state http.ConnState) {
+			if state == http.StateNew {
+				connections.Add(1)
+			}
+		}
+		server.Start()
+		defer server.Close()
+
+		transport := NewTransport(DefaultTransportSettings())
+		client := NewHttpClient("test-upstream", server.URL, time.Second, noop.NewTracerProvider().Tracer(""), WithTransport(transport))
+
+		for i := 0; i < 5; i++ {
+			var result response
+			s.Nil(client.Get(context.Background(), "/", &result))
+		}
+
+		s.Equal(int32(1), connections.Load())
+	})
+
+	s.Run("should enforce the configured timeout", func() {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			select {
+			case <-time.After(time.Second):
+			case <-r.Context().Done():
+			}
+		}))
+		defer server.Close()
+
+		client := NewHttpClient("test-upstream", server.URL, 50*time.Millisecond, noop.NewTracerProvider().Tracer(""))
+
+		start := time.Now()
+		var result response
+		err := client.Get(context.Background(), "/", &result)
+
+		s.Require().NotNil(err)
+		s.Less(time.Since(start), 500*time.Millisecond)
+
+		var netErr net.Error
+		s.True(errors.As(err, &netErr) && netErr.Timeout())
+	})
+}
@@ -1,6 +1,7 @@
 package logger
 
 import (
+	"io"
 	"os"
 	"time"
 
@@ -12,6 +13,7 @@ import (
 
 type Logger struct {
 	Level zerolog.Level
+	Out   io.Writer
 }
 
 type LoggerInterface interface {
@@ -23,6 +25,7 @@ type LoggerInterface interface {
 func NewLogger(level string) *Logger {
 	return &Logger{
 		Level: getLevel(level),
+		Out:   os.Stdout,
 	}
 }
 
@@ -30,7 +33,7 @@ func (l *Logger) Setup() {
 	zerolog.SetGlobalLevel(l.Level)
 
 	log.Logger = log.Output(zerolog.ConsoleWriter{
-		Out:        os.Stdout,
+		Out:        l.Out,
 		TimeFormat: time.RFC3339,
 	})
 }
@@ -0,0 +1,37 @@
+package cassettetest
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/trace/noop"
+
+	"github.com/wellalencarweb/otel-lab-challenge/internal/pkg/httpclient"
+)
+
+// NewHttpClient returns a real HttpClient replaying testdata/cassettes/<cassette>.
+// Run the tests with CASSETTE_MODE=record to hit the upstream and refresh the cassette.
+func NewHttpClient(t testing.TB, name string, baseURL string, cassette string) *httpclient.HttpClient {
+	mode := httpclient.CassetteReplay
+	if os.Getenv("CASSETTE_MODE") == string(httpclient.CassetteRecord) {
+		mode = httpclient.CassetteRecord
+	}
+
+	recorder, err := httpclient.NewRecorder(filepath.Join("testdata", "cassettes", cassette), mode, nil)
+	require.NoError(t, err)
+
+	t.Cleanup(func() {
+		require.NoError(t, recorder.Save())
+	})
+
+	return httpclient.NewHttpClient(
+		name,
+		baseURL,
+		5*time.Second,
+		noop.NewTracerProvider().Tracer(""),
+		httpclient.WithTransport(recorder),
+	)
+}
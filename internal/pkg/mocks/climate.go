@@ -12,7 +12,58 @@ type FindByCityNameUseCaseMock struct {
 	mock.Mock
 }
 
-func (m *FindByCityNameUseCaseMock) Execute(ctx context.Context, city string) (*entities.Climate, error) {
-	args := m.Called(ctx, city)
+func (m *FindByCityNameUseCaseMock) Execute(ctx context.Context, city string, region string) (*entities.Climate, error) {
+	args := m.Called(ctx, city, region)
 	return args.Get(0).(*entities.Climate), args.Error(1)
 }
+
+type FindForecastByCityNameUseCaseMock struct {
+	mock.Mock
+}
+
+func (m *FindForecastByCityNameUseCaseMock) Execute(ctx context.Context, city string, region string, days int) (*entities.Forecast, error) {
+	args := m.Called(ctx, city, region, days)
+	return args.Get(0).(*entities.Forecast), args.Error(1)
+}
+
+type FindHourlyForecastByCityNameUseCaseMock struct {
+	mock.Mock
+}
+
+func (m *FindHourlyForecastByCityNameUseCaseMock) Execute(ctx context.Context, city string, region string, hours int) (*entities.Forecast, error) {
+	args := m.Called(ctx, city, region, hours)
+	return args.Get(0).(*entities.Forecast), args.Error(1)
+}
+
+type WeatherProviderMock struct {
+	mock.Mock
+}
+
+func (m *WeatherProviderMock) Name() string {
+	args := m.Called()
+	return args.String(0)
+}
+
+func (m *WeatherProviderMock) CurrentWeather(ctx context.Context, query entities.ClimateQuery) (*entities.Climate, error) {
+	args := m.Called(ctx, query)
+	return args.Get(0).(*entities.Climate), args.Error(1)
+}
+
+func (m *WeatherProviderMock) Forecast(ctx context.Context, query entities.ClimateQuery, days int) (*entities.Forecast, error) {
+	args := m.Called(ctx, query, days)
+	return args.Get(0).(*entities.Forecast), args.Error(1)
+}
+
+func (m *WeatherProviderMock) HourlyForecast(ctx context.Context, query entities.ClimateQuery, hours int) (*entities.Forecast, error) {
+	args := m.Called(ctx, query, hours)
+	return args.Get(0).(*entities.Forecast), args.Error(1)
+}
+
+type GeocoderMock struct {
+	mock.Mock
+}
+
+func (m *GeocoderMock) Geocode(ctx context.Context, query entities.ClimateQuery) (*entities.ClimateLocation, error) {
+	args := m.Called(ctx, query)
+	return args.Get(0).(*entities.ClimateLocation), args.Error(1)
+}
@@ -11,8 +11,8 @@ type HttpClientMock struct {
 	mock.Mock
 }
 
-func (m *HttpClientMock) Get(ctx context.Context, endpoint string, responseObj interface{}) *httpclient.HttpClientError {
-	args := m.Called(ctx, endpoint, responseObj)
+func (m *HttpClientMock) Get(ctx context.Context, endpoint string, responseObj interface{}, opts ...httpclient.RequestOption) *httpclient.HttpClientError {
+	args := m.Called(ctx, endpoint, responseObj, httpclient.NewRequestOptions(opts...))
 
 	if args.Get(0) == nil {
 		return nil
@@ -20,3 +20,48 @@ func (m *HttpClientMock) Get(ctx context.Context, endpoint string, responseObj i
 
 	return args.Get(0).(*httpclient.HttpClientError)
 }
+
+func (m *HttpClientMock) Post(ctx context.Context, endpoint string, body interface{}, responseObj interface{}, opts ...httpclient.RequestOption) *httpclient.HttpClientError {
+	args := m.Called(ctx, endpoint, body, responseObj, httpclient.NewRequestOptions(opts...))
+
+	if args.Get(0) == nil {
+		return nil
+	}
+
+	return args.Get(0).(*httpclient.HttpClientError)
+}
+
+func (m *HttpClientMock) Put(ctx context.Context, endpoint string, body interface{}, responseObj interface{}, opts ...httpclient.RequestOption) *httpclient.HttpClientError {
+	args := m.Called(ctx, endpoint, body, responseObj, httpclient.NewRequestOptions(opts...))
+
+	if args.Get(0) == nil {
+		return nil
+	}
+
+	return args.Get(0).(*httpclient.HttpClientError)
+}
+
+func (m *HttpClientMock) Delete(ctx context.Context, endpoint string, responseObj interface{}, opts ...httpclient.RequestOption) *httpclient.HttpClientError {
+	args := m.Called(ctx, endpoint, responseObj, httpclient.NewRequestOptions(opts...))
+
+	if args.Get(0) == nil {
+		return nil
+	}
+
+	return args.Get(0).(*httpclient.HttpClientError)
+}
+
+func (m *HttpClientMock) Do(ctx context.Context, method string, endpoint string, body interface{}, opts ...httpclient.RequestOption) (*httpclient.Response, *httpclient.HttpClientError) {
+	args := m.Called(ctx, method, endpoint, body, httpclient.NewRequestOptions(opts...))
+
+	var response *httpclient.Response
+	if args.Get(0) != nil {
+		response = args.Get(0).(*httpclient.Response)
+	}
+
+	if args.Get(1) == nil {
+		return response, nil
+	}
+
+	return response, args.Get(1).(*httpclient.HttpClientError)
+}
@@ -16,3 +16,17 @@ func (m *FindByZipCodeUseCaseMock) Execute(ctx context.Context, city string) (*e
 	args := m.Called(ctx, city)
 	return args.Get(0).(*entities.Location), args.Error(1)
 }
+
+type LocationProviderMock struct {
+	mock.Mock
+}
+
+func (m *LocationProviderMock) Name() string {
+	args := m.Called()
+	return args.String(0)
+}
+
+func (m *LocationProviderMock) FindByZipCode(ctx context.Context, zipCode string) (*entities.Location, error) {
+	args := m.Called(ctx, zipCode)
+	return args.Get(0).(*entities.Location), args.Error(1)
+}
@@ -0,0 +1,50 @@
+package singleflight
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"golang.org/x/sync/singleflight"
+)
+
+const sharedKey = attribute.Key("singleflight.shared")
+
+type flight[V any] struct {
+	value  V
+	leader trace.SpanContext
+}
+
+// Group coalesces concurrent calls sharing the same key into a single call of fn. The call
+// runs on behalf of the first caller, without its cancellation so that the others are not
+// affected when it goes away; every other caller waits on its own context and gets a link
+// from its span to the leader's span.
+type Group[V any] struct {
+	group singleflight.Group
+}
+
+func (g *Group[V]) Do(ctx context.Context, key string, fn func(ctx context.Context) (V, error)) (V, error) {
+	span := trace.SpanFromContext(ctx)
+
+	result := g.group.DoChan(key, func() (interface{}, error) {
+		value, err := fn(context.WithoutCancel(ctx))
+
+		return flight[V]{value: value, leader: span.SpanContext()}, err
+	})
+
+	select {
+	case <-ctx.Done():
+		var zero V
+		return zero, ctx.Err()
+	case res := <-result:
+		f := res.Val.(flight[V])
+
+		shared := !f.leader.Equal(span.SpanContext())
+		if shared {
+			span.AddLink(trace.Link{SpanContext: f.leader})
+		}
+		span.SetAttributes(sharedKey.Bool(shared))
+
+		return f.value, res.Err
+	}
+}
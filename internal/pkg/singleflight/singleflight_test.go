@@ -0,0 +1,117 @@
+package singleflight
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+type SingleflightTestSuite struct {
+	suite.Suite
+	SpanRecorder *tracetest.SpanRecorder
+	Tracer       trace.Tracer
+}
+
+func TestSingleflight(t *testing.T) {
+	suite.Run(t, new(SingleflightTestSuite))
+}
+
+func (s *SingleflightTestSuite) SetupTest() {
+	s.SpanRecorder = tracetest.NewSpanRecorder()
+	s.Tracer = sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(s.SpanRecorder)).Tracer("singleflight-test")
+}
+
+func (s *SingleflightTestSuite) TestDo() {
+	s.Run("should share one call between concurrent callers and link their spans to the leader", func() {
+		var group Group[string]
+		var calls atomic.Int32
+		release := make(chan struct{})
+
+		var wg sync.WaitGroup
+		for i := 0; i < 5; i++ {
+			wg.Add(1)
+
+			go func() {
+				defer wg.Done()
+
+				ctx, span := s.Tracer.Start(context.Background(), "find-location-by-zipcode")
+				defer span.End()
+
+				value, err := group.Do(ctx, "22021001", func(ctx context.Context) (string, error) {
+					calls.Add(1)
+					<-release
+
+					return "Rio de Janeiro", nil
+				})
+
+				s.Nil(err)
+				s.Equal("Rio de Janeiro", value)
+			}()
+		}
+
+		time.Sleep(20 * time.Millisecond)
+		close(release)
+		wg.Wait()
+
+		s.Equal(int32(1), calls.Load())
+
+		spans := s.SpanRecorder.Ended()
+		s.Require().Len(spans, 5)
+
+		var leaders, followers []sdktrace.ReadOnlySpan
+		for _, span := range spans {
+			if len(span.Links()) == 0 {
+				leaders = append(leaders, span)
+			} else {
+				followers = append(followers, span)
+			}
+		}
+
+		s.Require().Len(leaders, 1)
+		for _, follower := range followers {
+			s.Equal(leaders[0].SpanContext().SpanID(), follower.Links()[0].SpanContext.SpanID())
+		}
+	})
+
+	s.Run("should share errors", func() {
+		var group Group[string]
+		expectedErr := errors.New("viacep is unavailable")
+
+		_, err := group.Do(context.Background(), "22021001", func(ctx context.Context) (string, error) {
+			return "", expectedErr
+		})
+
+		s.Equal(expectedErr, err)
+	})
+
+	s.Run("should stop waiting when the caller goes away without cancelling the shared call", func() {
+		var group Group[string]
+		done := make(chan error, 1)
+
+		ctx, cancel := context.WithCancel(context.Background())
+
+		go func() {
+			_, err := group.Do(ctx, "22021001", func(ctx context.Context) (string, error) {
+				time.Sleep(50 * time.Millisecond)
+				done <- ctx.Err()
+
+				return "Rio de Janeiro", nil
+			})
+
+			s.ErrorIs(err, context.Canceled)
+		}()
+
+		time.Sleep(10 * time.Millisecond)
+		cancel()
+
+		s.Nil(<-done)
+	})
+}
@@ -0,0 +1,295 @@
+package bulk
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/wellalencarweb/otel-lab-challenge/internal/entities/dto"
+	"github.com/wellalencarweb/otel-lab-challenge/internal/pkg/httpclient"
+	"github.com/wellalencarweb/otel-lab-challenge/internal/pkg/temperature"
+	"github.com/wellalencarweb/otel-lab-challenge/internal/usecases/climate"
+	"github.com/wellalencarweb/otel-lab-challenge/internal/usecases/location"
+)
+
+var zipCodePattern = regexp.MustCompile(`^\d{8}$`)
+
+type Settings struct {
+	Concurrency      int
+	ProgressInterval time.Duration
+}
+
+type ResolveZipCodesUseCaseInterface interface {
+	Execute(ctx context.Context, zipCodes <-chan string, emit func(dto.BulkResolveResult)) dto.BulkResolveSummary
+	Resolve(ctx context.Context, zipCodes <-chan string, emit func(dto.BulkResolveResult)) dto.BulkResolveSummary
+}
+
+// ResolveZipCodesUseCase resolves a stream of zipcodes into temperatures the same way the
+// orchestrator does, with at most Concurrency lookups in flight and, when Limiter is set, no
+// more zipcodes per second than it allows. Repeated zipcodes are resolved only once.
+type ResolveZipCodesUseCase struct {
+	FindLocationByZipCodeUseCase location.FindByZipCodeUseCaseInterface
+	FindClimateByCityNameUseCase climate.FindByCityNameUseCaseInterface
+	Limiter                      *httpclient.RateLimiter
+	Settings                     Settings
+	Logger                       zerolog.Logger
+	Tracer                       trace.Tracer
+}
+
+func NewResolveZipCodesUseCase(
+	findByZipCodeUC location.FindByZipCodeUseCaseInterface,
+	findByCityNameUC climate.FindByCityNameUseCaseInterface,
+	limiter *httpclient.RateLimiter,
+	settings Settings,
+	logger zerolog.Logger,
+	tracer trace.Tracer,
+) *ResolveZipCodesUseCase {
+	settings.Concurrency = max(settings.Concurrency, 1)
+
+	return &ResolveZipCodesUseCase{
+		FindLocationByZipCodeUseCase: findByZipCodeUC,
+		FindClimateByCityNameUseCase: findByCityNameUC,
+		Limiter:                      limiter,
+		Settings:                     settings,
+		Logger:                       logger,
+		Tracer:                       tracer,
+	}
+}
+
+// Execute reads zipcodes until the channel is closed or ctx is done, calling emit once per
+// distinct zipcode in completion order. emit is never called concurrently. Once ctx is done, the
+// zipcodes already buffered in the channel are emitted with ctx's error instead of resolved.
+func (uc *ResolveZipCodesUseCase) Execute(ctx context.Context, zipCodes <-chan string, emit func(dto.BulkResolveResult)) dto.BulkResolveSummary {
+	ctx, span := uc.Tracer.Start(ctx, "bulk-resolve")
+	defer span.End()
+
+	return uc.Resolve(ctx, zipCodes, emit)
+}
+
+// Resolve is Execute without a span of its own: each zipcode gets its span right under the one
+// in ctx, which also receives the run totals. It lets the batch endpoint trace the run itself.
+func (uc *ResolveZipCodesUseCase) Resolve(ctx context.Context, zipCodes <-chan string, emit func(dto.BulkResolveResult)) dto.BulkResolveSummary {
+	span := trace.SpanFromContext(ctx)
+	start := time.Now()
+
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	var summary dto.BulkResolveSummary
+
+	record := func(result dto.BulkResolveResult) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		if result.Error != "" {
+			summary.Failed++
+		} else {
+			summary.Resolved++
+		}
+
+		emit(result)
+	}
+
+	stopProgress := uc.reportProgress(&mu, &summary, start)
+	defer stopProgress()
+
+	seen := make(map[string]bool)
+
+	// accept counts a zipcode read and normalizes it, telling whether it has not been seen yet.
+	accept := func(zipCode string) (string, bool) {
+		zipCode = NormalizeZipCode(zipCode)
+
+		mu.Lock()
+		defer mu.Unlock()
+
+		summary.Total++
+		if seen[zipCode] {
+			summary.Duplicates++
+			return zipCode, false
+		}
+		seen[zipCode] = true
+
+		return zipCode, true
+	}
+
+	semaphore := make(chan struct{}, uc.Settings.Concurrency)
+
+read:
+	for {
+		var zipCode string
+		var ok bool
+
+		select {
+		case <-ctx.Done():
+			break read
+		case zipCode, ok = <-zipCodes:
+			if !ok {
+				break read
+			}
+		}
+
+		zipCode, fresh := accept(zipCode)
+		if !fresh {
+			continue
+		}
+
+		if err := uc.Limiter.Acquire(ctx); err != nil {
+			record(dto.BulkResolveResult{Zipcode: zipCode, Error: err.Error()})
+			continue
+		}
+
+		select {
+		case <-ctx.Done():
+			record(dto.BulkResolveResult{Zipcode: zipCode, Error: ctx.Err().Error()})
+			break read
+		case semaphore <- struct{}{}:
+		}
+
+		wg.Add(1)
+
+		go func(zipCode string) {
+			defer wg.Done()
+			defer func() { <-semaphore }()
+
+			record(uc.resolve(ctx, zipCode))
+		}(zipCode)
+	}
+
+	// Zipcodes already waiting when ctx is done still get a result, so a caller matching results
+	// to its input, such as the batch endpoint, does not lose them silently.
+	if err := ctx.Err(); err != nil {
+	drain:
+		for {
+			select {
+			case zipCode, ok := <-zipCodes:
+				if !ok {
+					break drain
+				}
+				if zipCode, fresh := accept(zipCode); fresh {
+					record(dto.BulkResolveResult{Zipcode: zipCode, Error: err.Error()})
+				}
+			default:
+				break drain
+			}
+		}
+	}
+
+	wg.Wait()
+
+	mu.Lock()
+	defer mu.Unlock()
+
+	summary.Duration = time.Since(start)
+
+	span.SetAttributes(
+		attribute.Int("bulk.total", summary.Total),
+		attribute.Int("bulk.resolved", summary.Resolved),
+		attribute.Int("bulk.failed", summary.Failed),
+	)
+
+	return summary
+}
+
+func (uc *ResolveZipCodesUseCase) resolve(ctx context.Context, zipCode string) dto.BulkResolveResult {
+	ctx, span := uc.Tracer.Start(ctx, "resolve-zipcode", trace.WithAttributes(attribute.String("zipcode", zipCode)))
+	defer span.End()
+
+	start := time.Now()
+	result := dto.BulkResolveResult{Zipcode: zipCode}
+
+	err := uc.lookup(ctx, &result)
+	if err != nil {
+		span.SetStatus(codes.Error, "error resolving zipcode")
+		span.RecordError(err)
+
+		result.Error = err.Error()
+	}
+
+	result.DurationMs = time.Since(start).Milliseconds()
+
+	return result
+}
+
+func (uc *ResolveZipCodesUseCase) lookup(ctx context.Context, result *dto.BulkResolveResult) error {
+	if !zipCodePattern.MatchString(result.Zipcode) {
+		return errors.New("invalid zipcode")
+	}
+
+	zipCodeCtx, zipCodeSpan := uc.Tracer.Start(ctx, "find-location-by-zipcode")
+	location, err := uc.FindLocationByZipCodeUseCase.Execute(zipCodeCtx, result.Zipcode)
+	zipCodeSpan.End()
+
+	if err != nil {
+		return err
+	}
+	if location.City == "" {
+		return errors.New("zipcode not found")
+	}
+
+	climateCtx, climateSpan := uc.Tracer.Start(ctx, "find-climate-by-city-name")
+	climate, err := uc.FindClimateByCityNameUseCase.Execute(climateCtx, location.City, location.State)
+	climateSpan.End()
+
+	if err != nil {
+		return err
+	}
+
+	fahrenheit, kelvin := temperature.ConvertCelcius(climate.Current.TempC)
+
+	result.State = location.State
+	result.GetTemperaturesByZipCodeOutput = &dto.GetTemperaturesByZipCodeOutput{
+		City:       location.City,
+		Celcius:    float32(climate.Current.TempC),
+		Fahrenheit: float32(fahrenheit),
+		Kelvin:     float32(kelvin),
+		Latitude:   climate.Location.Latitude,
+		Longitude:  climate.Location.Longitude,
+		Stale:      climate.Stale,
+		Consensus:  dto.NewTemperatureConsensusOutput(climate.Consensus),
+	}
+
+	return nil
+}
+
+func (uc *ResolveZipCodesUseCase) reportProgress(mu *sync.Mutex, summary *dto.BulkResolveSummary, start time.Time) func() {
+	if uc.Settings.ProgressInterval <= 0 {
+		return func() {}
+	}
+
+	stop := make(chan struct{})
+	ticker := time.NewTicker(uc.Settings.ProgressInterval)
+
+	go func() {
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-stop:
+				return
+			case <-ticker.C:
+				mu.Lock()
+				progress := *summary
+				mu.Unlock()
+
+				progress.Duration = time.Since(start)
+
+				uc.Logger.Info().Msgf("[BulkResolve] Read [%d] zipcodes, resolved [%d], failed [%d] in %s (%.1f/s)",
+					progress.Total, progress.Resolved, progress.Failed, progress.Duration.Round(time.Second), progress.PerSecond())
+			}
+		}
+	}()
+
+	return func() { close(stop) }
+}
+
+// NormalizeZipCode is the form results are keyed by: no surrounding blanks and no hyphen.
+func NormalizeZipCode(zipCode string) string {
+	return strings.ReplaceAll(strings.TrimSpace(zipCode), "-", "")
+}
@@ -0,0 +1,117 @@
+package bulk
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"go.opentelemetry.io/otel/trace/noop"
+
+	"github.com/wellalencarweb/otel-lab-challenge/internal/entities"
+	"github.com/wellalencarweb/otel-lab-challenge/internal/entities/dto"
+	"github.com/wellalencarweb/otel-lab-challenge/internal/pkg/customerrors"
+	"github.com/wellalencarweb/otel-lab-challenge/internal/pkg/httpclient"
+	"github.com/wellalencarweb/otel-lab-challenge/internal/pkg/mocks"
+)
+
+type ResolveZipCodesUseCaseTestSuite struct {
+	suite.Suite
+	FindByZipCodeUseCaseMock  *mocks.FindByZipCodeUseCaseMock
+	FindByCityNameUseCaseMock *mocks.FindByCityNameUseCaseMock
+}
+
+func TestResolveZipCodesUseCase(t *testing.T) {
+	suite.Run(t, new(ResolveZipCodesUseCaseTestSuite))
+}
+
+func (s *ResolveZipCodesUseCaseTestSuite) SetupTest() {
+	s.FindByZipCodeUseCaseMock = new(mocks.FindByZipCodeUseCaseMock)
+	s.FindByCityNameUseCaseMock = new(mocks.FindByCityNameUseCaseMock)
+}
+
+func (s *ResolveZipCodesUseCaseTestSuite) execute(uc *ResolveZipCodesUseCase, zipCodes ...string) (map[string]dto.BulkResolveResult, dto.BulkResolveSummary) {
+	in := make(chan string, len(zipCodes))
+	for _, zipCode := range zipCodes {
+		in <- zipCode
+	}
+	close(in)
+
+	results := make(map[string]dto.BulkResolveResult)
+	summary := uc.Execute(context.Background(), in, func(result dto.BulkResolveResult) {
+		results[result.Zipcode] = result
+	})
+
+	return results, summary
+}
+
+func (s *ResolveZipCodesUseCaseTestSuite) newUseCase(limiter *httpclient.RateLimiter) *ResolveZipCodesUseCase {
+	return NewResolveZipCodesUseCase(s.FindByZipCodeUseCaseMock, s.FindByCityNameUseCaseMock, limiter, Settings{Concurrency: 4}, zerolog.Nop(), noop.NewTracerProvider().Tracer("bulk-test"))
+}
+
+func (s *ResolveZipCodesUseCaseTestSuite) TestExecute() {
+	s.FindByZipCodeUseCaseMock.On("Execute", mock.Anything, "22021001").Return(&entities.Location{City: "Rio de Janeiro", State: "RJ"}, nil).Once()
+	s.FindByZipCodeUseCaseMock.On("Execute", mock.Anything, "99999999").Return((*entities.Location)(nil), &customerrors.NotFoundError{
+		Err:     errors.New("zipcode not found"),
+		Message: "can not find zipcode",
+	}).Once()
+	s.FindByCityNameUseCaseMock.On("Execute", mock.Anything, "Rio de Janeiro", "RJ").Return(&entities.Climate{
+		Current: entities.ClimateData{TempC: 30},
+	}, nil).Once()
+
+	results, summary := s.execute(s.newUseCase(nil), "22021-001", "22021001", "99999999", "123")
+
+	s.Equal(4, summary.Total)
+	s.Equal(1, summary.Duplicates)
+	s.Equal(1, summary.Resolved)
+	s.Equal(2, summary.Failed)
+
+	s.Equal("RJ", results["22021001"].State)
+	s.Equal(&dto.GetTemperaturesByZipCodeOutput{City: "Rio de Janeiro", Celcius: 30, Fahrenheit: 86, Kelvin: 303.15}, results["22021001"].GetTemperaturesByZipCodeOutput)
+	s.Equal("can not find zipcode", results["99999999"].Error)
+	s.Equal("invalid zipcode", results["123"].Error)
+
+	s.FindByZipCodeUseCaseMock.AssertExpectations(s.T())
+	s.FindByCityNameUseCaseMock.AssertExpectations(s.T())
+}
+
+func (s *ResolveZipCodesUseCaseTestSuite) TestRateLimit() {
+	s.FindByZipCodeUseCaseMock.On("Execute", mock.Anything, mock.Anything).Return(&entities.Location{}, nil)
+
+	limiter := httpclient.NewRateLimiter("bulk-test", httpclient.RateLimitSettings{Rate: 20, Burst: 1, Mode: httpclient.RateLimitWait})
+
+	start := time.Now()
+	_, summary := s.execute(s.newUseCase(limiter), "22021001", "22021002", "22021003", "22021004", "22021005")
+
+	s.Equal(5, summary.Failed)
+	s.GreaterOrEqual(time.Since(start), 190*time.Millisecond)
+}
+
+func (s *ResolveZipCodesUseCaseTestSuite) TestCancelledContext() {
+	s.FindByZipCodeUseCaseMock.On("Execute", mock.Anything, mock.Anything).Return((*entities.Location)(nil), context.Canceled).Maybe()
+
+	in := make(chan string, 4)
+	for _, zipCode := range []string{"22021001", "22041001", "22041-001", "01001000"} {
+		in <- zipCode
+	}
+	close(in)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	results := make(map[string]dto.BulkResolveResult)
+	summary := s.newUseCase(nil).Execute(ctx, in, func(result dto.BulkResolveResult) {
+		results[result.Zipcode] = result
+	})
+
+	s.Equal(4, summary.Total)
+	s.Equal(1, summary.Duplicates)
+	s.Equal(3, summary.Failed)
+	s.Len(results, 3)
+	for _, zipCode := range []string{"22021001", "22041001", "01001000"} {
+		s.Equal(context.Canceled.Error(), results[zipCode].Error)
+	}
+}
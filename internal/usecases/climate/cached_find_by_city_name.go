@@ -0,0 +1,205 @@
+package climate
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+
+	"github.com/rs/zerolog"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"golang.org/x/text/runes"
+	"golang.org/x/text/transform"
+	"golang.org/x/text/unicode/norm"
+
+	"github.com/wellalencarweb/otel-lab-challenge/internal/entities"
+	"github.com/wellalencarweb/otel-lab-challenge/internal/pkg/cache"
+	"github.com/wellalencarweb/otel-lab-challenge/internal/pkg/customerrors"
+)
+
+const (
+	cacheHitKey    = attribute.Key("cache.hit")
+	cacheResultKey = attribute.Key("cache.result")
+)
+
+// minFreshness keeps an entry fresh for a while even when the provider hands back a reading that
+// is already older than TTL, so it is not revalidated on every request.
+const minFreshness = time.Minute
+
+type CacheSettings struct {
+	TTL                  time.Duration
+	StaleWhileRevalidate time.Duration
+	StaleIfError         time.Duration
+}
+
+type CachedClimate struct {
+	Climate    entities.Climate `json:"climate"`
+	FreshUntil time.Time        `json:"fresh_until"`
+}
+
+// CachedFindByCityNameUseCase decorates a FindByCityNameUseCaseInterface with a cache keyed by
+// normalized city and region. An entry is fresh for TTL after the reading was observed;
+// past that it is served stale for StaleWhileRevalidate while being refreshed in the background,
+// and is still used for StaleIfError when the upstream fails. Stale answers are flagged.
+type CachedFindByCityNameUseCase struct {
+	Next     FindByCityNameUseCaseInterface
+	Cache    cache.Backend[CachedClimate]
+	Settings CacheSettings
+	Logger   zerolog.Logger
+
+	mu         sync.Mutex
+	refreshing map[string]bool
+	now        func() time.Time
+}
+
+func NewCachedFindByCityNameUseCase(
+	next FindByCityNameUseCaseInterface,
+	backend cache.Backend[CachedClimate],
+	settings CacheSettings,
+	logger zerolog.Logger,
+) *CachedFindByCityNameUseCase {
+	return &CachedFindByCityNameUseCase{
+		Next:       next,
+		Cache:      backend,
+		Settings:   settings,
+		Logger:     logger,
+		refreshing: make(map[string]bool),
+		now:        time.Now,
+	}
+}
+
+func (uc *CachedFindByCityNameUseCase) Execute(ctx context.Context, city string, region string) (*entities.Climate, error) {
+	span := trace.SpanFromContext(ctx)
+	key := CacheKey(city, region)
+	now := uc.now()
+
+	cached, ok := uc.Cache.Get(key)
+	if ok && now.Before(cached.FreshUntil) {
+		span.SetAttributes(cacheHitKey.Bool(true), cacheResultKey.String("hit"))
+
+		return cached.copy(false), nil
+	}
+
+	if ok && now.Before(cached.FreshUntil.Add(uc.Settings.StaleWhileRevalidate)) {
+		span.SetAttributes(cacheHitKey.Bool(true), cacheResultKey.String("stale"))
+		uc.revalidate(ctx, key, city, region)
+
+		return cached.copy(true), nil
+	}
+
+	span.SetAttributes(cacheHitKey.Bool(false), cacheResultKey.String("miss"))
+
+	climate, err := uc.Next.Execute(ctx, city, region)
+	if err == nil {
+		uc.store(key, climate)
+
+		return climate, nil
+	}
+
+	var notFoundErr *customerrors.NotFoundError
+	if ok && !errors.As(err, &notFoundErr) {
+		uc.Logger.Warn().Msgf("[CachedFindByCityName] Serving stale climate for [%s] after upstream error: %s", key, err)
+		span.SetAttributes(cacheResultKey.String("stale_if_error"))
+
+		return cached.copy(true), nil
+	}
+
+	return nil, err
+}
+
+func (uc *CachedFindByCityNameUseCase) store(key string, climate *entities.Climate) {
+	now := uc.now()
+
+	lastUpdated := now
+	if !climate.Current.ObservedAt.IsZero() {
+		lastUpdated = climate.Current.ObservedAt
+	}
+
+	freshUntil := lastUpdated.Add(uc.Settings.TTL)
+	if minFreshUntil := now.Add(min(minFreshness, uc.Settings.TTL)); freshUntil.Before(minFreshUntil) {
+		freshUntil = minFreshUntil
+	}
+
+	retention := freshUntil.Sub(now) + max(uc.Settings.StaleWhileRevalidate, uc.Settings.StaleIfError)
+
+	uc.Cache.Set(key, CachedClimate{Climate: *climate, FreshUntil: freshUntil}, retention)
+}
+
+// revalidate refreshes a stale entry in the background, at most once at a time per key. The
+// refresh outlives the request, so it runs in its own trace linked to the request's span.
+func (uc *CachedFindByCityNameUseCase) revalidate(ctx context.Context, key string, city string, region string) {
+	uc.mu.Lock()
+	defer uc.mu.Unlock()
+
+	if uc.refreshing[key] {
+		return
+	}
+
+	uc.refreshing[key] = true
+
+	parent := trace.SpanFromContext(ctx)
+	ctx, span := parent.TracerProvider().Tracer("climate").Start(
+		context.WithoutCancel(ctx),
+		"revalidate-climate-by-city-name",
+		trace.WithNewRoot(),
+		trace.WithLinks(trace.Link{SpanContext: parent.SpanContext()}),
+	)
+
+	go func() {
+		defer func() {
+			span.End()
+
+			uc.mu.Lock()
+			delete(uc.refreshing, key)
+			uc.mu.Unlock()
+		}()
+
+		climate, err := uc.Next.Execute(ctx, city, region)
+		if err != nil {
+			uc.Logger.Warn().Msgf("[CachedFindByCityName] Error revalidating climate for [%s]: %s", key, err)
+			span.RecordError(err)
+			return
+		}
+
+		uc.store(key, climate)
+	}()
+}
+
+func (c CachedClimate) copy(stale bool) *entities.Climate {
+	climate := c.Climate
+	climate.Stale = stale
+
+	return &climate
+}
+
+// CacheKey normalizes case, accents and spacing, so "São José" and "sao  jose" share an entry,
+// while keeping cities with the same name in different regions apart. Climates and geocoded
+// places are both cached under it.
+func CacheKey(city string, region string) string {
+	return foldKey(city) + "|" + foldKey(region)
+}
+
+// NormalizeCacheKey puts a key typed by hand, such as "São Paulo|SP", or a prefix of one, in the
+// form CacheKey gives it.
+func NormalizeCacheKey(key string) string {
+	parts := strings.Split(key, "|")
+	for i, part := range parts {
+		parts[i] = foldKey(part)
+	}
+
+	return strings.Join(parts, "|")
+}
+
+func foldKey(value string) string {
+	foldAccents := transform.Chain(norm.NFD, runes.Remove(runes.In(unicode.Mn)), norm.NFC)
+
+	folded, _, err := transform.String(foldAccents, value)
+	if err != nil {
+		folded = value
+	}
+
+	return strings.Join(strings.Fields(strings.ToLower(folded)), " ")
+}
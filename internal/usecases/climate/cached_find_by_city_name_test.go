@@ -0,0 +1,186 @@
+package climate
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/wellalencarweb/otel-lab-challenge/internal/entities"
+	"github.com/wellalencarweb/otel-lab-challenge/internal/pkg/cache"
+	"github.com/wellalencarweb/otel-lab-challenge/internal/pkg/customerrors"
+	"github.com/wellalencarweb/otel-lab-challenge/internal/pkg/mocks"
+)
+
+type CachedFindByCityNameUseCaseTestSuite struct {
+	suite.Suite
+	FindByCityNameUseCaseMock   *mocks.FindByCityNameUseCaseMock
+	CachedFindByCityNameUseCase *CachedFindByCityNameUseCase
+	Now                         time.Time
+}
+
+func TestCachedFindByCityNameUseCase(t *testing.T) {
+	suite.Run(t, new(CachedFindByCityNameUseCaseTestSuite))
+}
+
+func (s *CachedFindByCityNameUseCaseTestSuite) SetupTest() {
+	s.Now = time.Now()
+	s.FindByCityNameUseCaseMock = new(mocks.FindByCityNameUseCaseMock)
+	s.CachedFindByCityNameUseCase = NewCachedFindByCityNameUseCase(s.FindByCityNameUseCaseMock, cache.NewLRU[CachedClimate](10), CacheSettings{
+		TTL:                  10 * time.Minute,
+		StaleWhileRevalidate: 5 * time.Minute,
+		StaleIfError:         time.Hour,
+	}, zerolog.Nop())
+	s.CachedFindByCityNameUseCase.now = func() time.Time { return s.Now }
+}
+
+func (s *CachedFindByCityNameUseCaseTestSuite) climate(tempC float64) *entities.Climate {
+	return &entities.Climate{
+		Current: entities.ClimateData{
+			ObservedAt: s.Now,
+			TempC:      tempC,
+		},
+	}
+}
+
+func (s *CachedFindByCityNameUseCaseTestSuite) waitForRevalidation() {
+	s.Eventually(func() bool {
+		s.CachedFindByCityNameUseCase.mu.Lock()
+		defer s.CachedFindByCityNameUseCase.mu.Unlock()
+
+		return len(s.CachedFindByCityNameUseCase.refreshing) == 0
+	}, time.Second, time.Millisecond)
+}
+
+func (s *CachedFindByCityNameUseCaseTestSuite) TestCachedFindByCityNameUseCase() {
+	ctx := context.Background()
+
+	s.Run("should serve fresh entries from cache regardless of case and accents", func() {
+		s.FindByCityNameUseCaseMock.On("Execute", mock.Anything, "São José", "SC").Return(s.climate(20), nil).Once()
+
+		result, err := s.CachedFindByCityNameUseCase.Execute(ctx, "São José", "SC")
+		s.Nil(err)
+		s.Equal(20.0, result.Current.TempC)
+
+		result, err = s.CachedFindByCityNameUseCase.Execute(ctx, " sao  jose ", "sc")
+		s.Nil(err)
+		s.Equal(20.0, result.Current.TempC)
+		s.False(result.Stale)
+
+		s.FindByCityNameUseCaseMock.AssertExpectations(s.T())
+	})
+
+	s.Run("should keep cities with the same name in different regions apart", func() {
+		s.FindByCityNameUseCaseMock.On("Execute", mock.Anything, "São José", "SP").Return(s.climate(25), nil).Once()
+
+		result, err := s.CachedFindByCityNameUseCase.Execute(ctx, "São José", "SP")
+		s.Nil(err)
+		s.Equal(25.0, result.Current.TempC)
+
+		s.FindByCityNameUseCaseMock.AssertExpectations(s.T())
+	})
+}
+
+func (s *CachedFindByCityNameUseCaseTestSuite) TestRegionReachesTheProvider() {
+	providerMock := new(mocks.WeatherProviderMock)
+	providerMock.On("Name").Return("weatherapi")
+	providerMock.On("CurrentWeather", mock.Anything, entities.ClimateQuery{City: "São José", Region: "SC"}).Return(s.climate(20), nil).Once()
+	providerMock.On("CurrentWeather", mock.Anything, entities.ClimateQuery{City: "São José", Region: "SP"}).Return(s.climate(25), nil).Once()
+
+	s.CachedFindByCityNameUseCase.Next = NewFindByCityNameUseCase(providerMock, nil, zerolog.Nop())
+
+	result, err := s.CachedFindByCityNameUseCase.Execute(context.Background(), "São José", "SC")
+	s.Require().NoError(err)
+	s.Equal(20.0, result.Current.TempC)
+
+	result, err = s.CachedFindByCityNameUseCase.Execute(context.Background(), "São José", "SP")
+	s.Require().NoError(err)
+	s.Equal(25.0, result.Current.TempC)
+
+	providerMock.AssertExpectations(s.T())
+}
+
+func (s *CachedFindByCityNameUseCaseTestSuite) TestStaleWhileRevalidate() {
+	ctx := context.Background()
+
+	s.FindByCityNameUseCaseMock.On("Execute", mock.Anything, "Rio de Janeiro", "RJ").Return(s.climate(30), nil).Once()
+	_, err := s.CachedFindByCityNameUseCase.Execute(ctx, "Rio de Janeiro", "RJ")
+	s.Require().NoError(err)
+
+	s.Now = s.Now.Add(12 * time.Minute)
+	s.FindByCityNameUseCaseMock.On("Execute", mock.Anything, "Rio de Janeiro", "RJ").Return(s.climate(31), nil).Once()
+
+	result, err := s.CachedFindByCityNameUseCase.Execute(ctx, "Rio de Janeiro", "RJ")
+	s.Nil(err)
+	s.Equal(30.0, result.Current.TempC)
+	s.True(result.Stale)
+
+	s.waitForRevalidation()
+
+	result, err = s.CachedFindByCityNameUseCase.Execute(ctx, "Rio de Janeiro", "RJ")
+	s.Nil(err)
+	s.Equal(31.0, result.Current.TempC)
+	s.False(result.Stale)
+
+	s.FindByCityNameUseCaseMock.AssertExpectations(s.T())
+}
+
+func (s *CachedFindByCityNameUseCaseTestSuite) TestStaleIfError() {
+	ctx := context.Background()
+
+	s.FindByCityNameUseCaseMock.On("Execute", mock.Anything, "Rio de Janeiro", "RJ").Return(s.climate(30), nil).Once()
+	_, err := s.CachedFindByCityNameUseCase.Execute(ctx, "Rio de Janeiro", "RJ")
+	s.Require().NoError(err)
+
+	s.Now = s.Now.Add(30 * time.Minute)
+
+	s.Run("should fall back to stale data when the upstream fails", func() {
+		s.FindByCityNameUseCaseMock.On("Execute", mock.Anything, "Rio de Janeiro", "RJ").Return((*entities.Climate)(nil), &customerrors.ServiceUnavailableError{
+			Err: errors.New("weatherapi is unavailable"),
+		}).Once()
+
+		result, err := s.CachedFindByCityNameUseCase.Execute(ctx, "Rio de Janeiro", "RJ")
+		s.Nil(err)
+		s.Equal(30.0, result.Current.TempC)
+		s.True(result.Stale)
+	})
+
+	s.Run("should not hide not found errors", func() {
+		notFoundErr := &customerrors.NotFoundError{Message: "can not find climate for city"}
+		s.FindByCityNameUseCaseMock.On("Execute", mock.Anything, "Rio de Janeiro", "RJ").Return((*entities.Climate)(nil), notFoundErr).Once()
+
+		result, err := s.CachedFindByCityNameUseCase.Execute(ctx, "Rio de Janeiro", "RJ")
+		s.Nil(result)
+		s.Equal(notFoundErr, err)
+	})
+
+	s.FindByCityNameUseCaseMock.AssertExpectations(s.T())
+}
+
+func (s *CachedFindByCityNameUseCaseTestSuite) TestFreshness() {
+	s.Run("should honour the observation time", func() {
+		climate := s.climate(30)
+		climate.Current.ObservedAt = s.Now.Add(-8 * time.Minute)
+
+		s.CachedFindByCityNameUseCase.store("rio de janeiro|rj", climate)
+
+		cached, ok := s.CachedFindByCityNameUseCase.Cache.Get("rio de janeiro|rj")
+		s.True(ok)
+		s.Equal(s.Now.Add(2*time.Minute).Unix(), cached.FreshUntil.Unix())
+	})
+
+	s.Run("should keep old readings fresh for a minimum period", func() {
+		climate := s.climate(30)
+		climate.Current.ObservedAt = s.Now.Add(-time.Hour)
+
+		s.CachedFindByCityNameUseCase.store("rio de janeiro|rj", climate)
+
+		cached, ok := s.CachedFindByCityNameUseCase.Cache.Get("rio de janeiro|rj")
+		s.True(ok)
+		s.Equal(s.Now.Add(minFreshness), cached.FreshUntil)
+	})
+}
@@ -0,0 +1,42 @@
+package climate
+
+import (
+	"context"
+	"time"
+
+	"github.com/wellalencarweb/otel-lab-challenge/internal/entities"
+	"github.com/wellalencarweb/otel-lab-challenge/internal/pkg/cache"
+)
+
+// CachedGeocoder decorates a Geocoder with a cache keyed by normalized city and region. Places
+// do not move, so only successful lookups are kept, for TTL.
+type CachedGeocoder struct {
+	Next  Geocoder
+	Cache cache.Backend[entities.ClimateLocation]
+	TTL   time.Duration
+}
+
+func NewCachedGeocoder(next Geocoder, backend cache.Backend[entities.ClimateLocation], ttl time.Duration) *CachedGeocoder {
+	return &CachedGeocoder{
+		Next:  next,
+		Cache: backend,
+		TTL:   ttl,
+	}
+}
+
+func (g *CachedGeocoder) Geocode(ctx context.Context, query entities.ClimateQuery) (*entities.ClimateLocation, error) {
+	key := CacheKey(query.City, query.Region)
+
+	if place, ok := g.Cache.Get(key); ok {
+		return &place, nil
+	}
+
+	place, err := g.Next.Geocode(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+
+	g.Cache.Set(key, *place, g.TTL)
+
+	return place, nil
+}
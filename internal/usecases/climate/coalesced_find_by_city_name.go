@@ -0,0 +1,35 @@
+package climate
+
+import (
+	"context"
+
+	"github.com/wellalencarweb/otel-lab-challenge/internal/entities"
+	"github.com/wellalencarweb/otel-lab-challenge/internal/pkg/singleflight"
+)
+
+// CoalescedFindByCityNameUseCase shares a single upstream lookup between concurrent callers
+// asking for the same city and region.
+type CoalescedFindByCityNameUseCase struct {
+	Next FindByCityNameUseCaseInterface
+
+	group singleflight.Group[*entities.Climate]
+}
+
+func NewCoalescedFindByCityNameUseCase(next FindByCityNameUseCaseInterface) *CoalescedFindByCityNameUseCase {
+	return &CoalescedFindByCityNameUseCase{
+		Next: next,
+	}
+}
+
+func (uc *CoalescedFindByCityNameUseCase) Execute(ctx context.Context, city string, region string) (*entities.Climate, error) {
+	climate, err := uc.group.Do(ctx, CacheKey(city, region), func(ctx context.Context) (*entities.Climate, error) {
+		return uc.Next.Execute(ctx, city, region)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	result := *climate
+
+	return &result, nil
+}
@@ -0,0 +1,195 @@
+package climate
+
+import (
+	"context"
+	"errors"
+	"math"
+	"slices"
+	"sync"
+
+	"github.com/rs/zerolog"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/wellalencarweb/otel-lab-challenge/internal/entities"
+	"github.com/wellalencarweb/otel-lab-challenge/internal/pkg/customerrors"
+)
+
+// WeatherProviderConsensus queries all its providers in parallel, each in its own span and
+// bounded by its own timeout, and answers with the median temperature of the readings within
+// MaxDeviation of the median of them all. Readings further away are flagged as outliers; when
+// no reading is close enough to another, none is discarded. It fails only when every provider
+// does, preferring an outage over a not found so callers may still serve stale data.
+type WeatherProviderConsensus struct {
+	Providers    []ChainedProvider
+	MaxDeviation float64
+	Logger       zerolog.Logger
+}
+
+func NewWeatherProviderConsensus(logger zerolog.Logger, maxDeviation float64, providers ...ChainedProvider) *WeatherProviderConsensus {
+	return &WeatherProviderConsensus{
+		Providers:    providers,
+		MaxDeviation: maxDeviation,
+		Logger:       logger,
+	}
+}
+
+func (c *WeatherProviderConsensus) Name() string {
+	return "consensus"
+}
+
+func (c *WeatherProviderConsensus) CurrentWeather(ctx context.Context, query entities.ClimateQuery) (*entities.Climate, error) {
+	span := trace.SpanFromContext(ctx)
+	tracer := span.TracerProvider().Tracer("climate")
+
+	climates := make([]*entities.Climate, len(c.Providers))
+	errs := make([]error, len(c.Providers))
+
+	var wg sync.WaitGroup
+	for i, chained := range c.Providers {
+		wg.Add(1)
+
+		go func(i int, chained ChainedProvider) {
+			defer wg.Done()
+
+			climates[i], errs[i] = c.fetch(ctx, tracer, chained, query)
+		}(i, chained)
+	}
+	wg.Wait()
+
+	readings := make([]entities.ClimateReading, len(c.Providers))
+	var temperatures []float64
+
+	for i, chained := range c.Providers {
+		readings[i].Provider = chained.Provider.Name()
+
+		if errs[i] != nil {
+			readings[i].Error = errs[i].Error()
+			continue
+		}
+
+		readings[i].TempC = climates[i].Current.TempC
+		temperatures = append(temperatures, climates[i].Current.TempC)
+	}
+
+	if len(temperatures) == 0 {
+		return nil, consensusError(errs)
+	}
+
+	center := median(temperatures)
+
+	var agreed []float64
+	for i := range readings {
+		if errs[i] != nil {
+			continue
+		}
+
+		if c.MaxDeviation > 0 && math.Abs(readings[i].TempC-center) > c.MaxDeviation {
+			readings[i].Outlier = true
+			continue
+		}
+
+		agreed = append(agreed, readings[i].TempC)
+	}
+
+	if len(agreed) == 0 {
+		for i := range readings {
+			readings[i].Outlier = false
+		}
+
+		agreed = temperatures
+	}
+
+	temperature := median(agreed)
+	spread := slices.Max(agreed) - slices.Min(agreed)
+
+	// the reading closest to the agreed temperature lends its location and conditions
+	var closest *entities.Climate
+	for i, climate := range climates {
+		if climate == nil || readings[i].Outlier {
+			continue
+		}
+
+		if closest == nil || math.Abs(climate.Current.TempC-temperature) < math.Abs(closest.Current.TempC-temperature) {
+			closest = climate
+		}
+	}
+
+	result := *closest
+	result.Provider = c.Name()
+	result.Current.TempC = temperature
+	result.Consensus = &entities.ClimateConsensus{
+		Readings: readings,
+		SpreadC:  spread,
+	}
+
+	span.SetAttributes(
+		attribute.Int("weather.consensus.readings", len(temperatures)),
+		attribute.Int("weather.consensus.outliers", len(temperatures)-len(agreed)),
+		attribute.Float64("weather.consensus.spread_c", spread),
+	)
+
+	return &result, nil
+}
+
+// Forecasts are not reconciled between providers: they fall back through them in order, as a
+// chain does.
+func (c *WeatherProviderConsensus) Forecast(ctx context.Context, query entities.ClimateQuery, days int) (*entities.Forecast, error) {
+	return NewWeatherProviderChain(c.Logger, c.Providers...).Forecast(ctx, query, days)
+}
+
+func (c *WeatherProviderConsensus) HourlyForecast(ctx context.Context, query entities.ClimateQuery, hours int) (*entities.Forecast, error) {
+	return NewWeatherProviderChain(c.Logger, c.Providers...).HourlyForecast(ctx, query, hours)
+}
+
+func (c *WeatherProviderConsensus) fetch(ctx context.Context, tracer trace.Tracer, chained ChainedProvider, query entities.ClimateQuery) (*entities.Climate, error) {
+	ctx, span := tracer.Start(ctx, "fetch-weather", trace.WithAttributes(weatherProviderKey.String(chained.Provider.Name())))
+	defer span.End()
+
+	if chained.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, chained.Timeout)
+		defer cancel()
+	}
+
+	climate, err := chained.Provider.CurrentWeather(ctx, query)
+	if err != nil {
+		span.SetStatus(codes.Error, "error fetching weather")
+		span.RecordError(err)
+		c.Logger.Warn().Msgf("[WeatherProviderConsensus] Provider [%s] failed for city [%s]: %s", chained.Provider.Name(), query.City, err)
+
+		return nil, err
+	}
+
+	span.SetAttributes(attribute.Float64("weather.temp_c", climate.Current.TempC))
+
+	return climate, nil
+}
+
+func consensusError(errs []error) error {
+	if len(errs) == 0 {
+		return errors.New("no weather provider configured")
+	}
+
+	for _, err := range errs {
+		var notFoundErr *customerrors.NotFoundError
+		if !errors.As(err, &notFoundErr) {
+			return err
+		}
+	}
+
+	return errs[0]
+}
+
+func median(values []float64) float64 {
+	sorted := slices.Clone(values)
+	slices.Sort(sorted)
+
+	middle := len(sorted) / 2
+	if len(sorted)%2 == 0 {
+		return (sorted[middle-1] + sorted[middle]) / 2
+	}
+
+	return sorted[middle]
+}
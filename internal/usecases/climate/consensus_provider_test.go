@@ -0,0 +1,135 @@
+package climate
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+
+	"github.com/wellalencarweb/otel-lab-challenge/internal/entities"
+	"github.com/wellalencarweb/otel-lab-challenge/internal/pkg/customerrors"
+	"github.com/wellalencarweb/otel-lab-challenge/internal/pkg/mocks"
+)
+
+type WeatherProviderConsensusTestSuite struct {
+	suite.Suite
+	Providers []*mocks.WeatherProviderMock
+	Recorder  *tracetest.SpanRecorder
+	Consensus *WeatherProviderConsensus
+}
+
+func TestWeatherProviderConsensus(t *testing.T) {
+	suite.Run(t, new(WeatherProviderConsensusTestSuite))
+}
+
+func (s *WeatherProviderConsensusTestSuite) SetupTest() {
+	s.Providers = nil
+
+	var chained []ChainedProvider
+	for _, name := range []string{"weatherapi", "openmeteo", "metno"} {
+		provider := new(mocks.WeatherProviderMock)
+		provider.On("Name").Return(name)
+
+		s.Providers = append(s.Providers, provider)
+		chained = append(chained, ChainedProvider{Provider: provider})
+	}
+
+	s.Recorder = tracetest.NewSpanRecorder()
+	s.Consensus = NewWeatherProviderConsensus(zerolog.Nop(), 3, chained...)
+}
+
+func (s *WeatherProviderConsensusTestSuite) respond(provider *mocks.WeatherProviderMock, tempC float64, err error) {
+	if err != nil {
+		provider.On("CurrentWeather", mock.Anything, mock.Anything).Return((*entities.Climate)(nil), err).Once()
+		return
+	}
+
+	provider.On("CurrentWeather", mock.Anything, mock.Anything).Return(&entities.Climate{
+		Provider: "any",
+		Current:  entities.ClimateData{TempC: tempC},
+	}, nil).Once()
+}
+
+func (s *WeatherProviderConsensusTestSuite) execute() (*entities.Climate, error) {
+	tracer := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(s.Recorder)).Tracer("climate-test")
+	ctx, span := tracer.Start(context.Background(), "find-climate-by-city-name")
+	defer span.End()
+
+	return s.Consensus.CurrentWeather(ctx, entities.ClimateQuery{City: "Rio de Janeiro", Region: "RJ"})
+}
+
+func (s *WeatherProviderConsensusTestSuite) TestCurrentWeather() {
+	s.Run("should answer the median and flag outliers", func() {
+		s.SetupTest()
+		s.respond(s.Providers[0], 27, nil)
+		s.respond(s.Providers[1], 28, nil)
+		s.respond(s.Providers[2], 40, nil)
+
+		climate, err := s.execute()
+
+		s.Require().NoError(err)
+		s.Equal("consensus", climate.Provider)
+		s.Equal(27.5, climate.Current.TempC)
+		s.Equal(1.0, climate.Consensus.SpreadC)
+		s.Equal([]entities.ClimateReading{
+			{Provider: "weatherapi", TempC: 27},
+			{Provider: "openmeteo", TempC: 28},
+			{Provider: "metno", TempC: 40, Outlier: true},
+		}, climate.Consensus.Readings)
+
+		spans := s.Recorder.Ended()
+		parent := spans[len(spans)-1]
+		s.Equal("find-climate-by-city-name", parent.Name())
+		s.Len(spans, 4)
+		for _, child := range spans[:3] {
+			s.Equal("fetch-weather", child.Name())
+			s.Equal(parent.SpanContext().SpanID(), child.Parent().SpanID())
+		}
+	})
+
+	s.Run("should keep answering when some providers fail", func() {
+		s.SetupTest()
+		s.respond(s.Providers[0], 0, errors.New("unavailable"))
+		s.respond(s.Providers[1], 28, nil)
+		s.respond(s.Providers[2], 29, nil)
+
+		climate, err := s.execute()
+
+		s.Require().NoError(err)
+		s.Equal(28.5, climate.Current.TempC)
+		s.Equal("unavailable", climate.Consensus.Readings[0].Error)
+	})
+
+	s.Run("should keep every reading when none agree", func() {
+		s.SetupTest()
+		s.respond(s.Providers[0], 10, nil)
+		s.respond(s.Providers[1], 20, nil)
+		s.respond(s.Providers[2], 0, &customerrors.NotFoundError{Message: "can not find climate for city"})
+
+		climate, err := s.execute()
+
+		s.Require().NoError(err)
+		s.Equal(15.0, climate.Current.TempC)
+		s.Equal(10.0, climate.Consensus.SpreadC)
+		s.False(climate.Consensus.Readings[0].Outlier)
+		s.False(climate.Consensus.Readings[1].Outlier)
+	})
+
+	s.Run("should prefer an outage over not found when every provider fails", func() {
+		s.SetupTest()
+		unavailableErr := &customerrors.ServiceUnavailableError{Err: errors.New("unavailable")}
+		s.respond(s.Providers[0], 0, &customerrors.NotFoundError{Message: "can not find climate for city"})
+		s.respond(s.Providers[1], 0, unavailableErr)
+		s.respond(s.Providers[2], 0, &customerrors.NotFoundError{Message: "can not find climate for city"})
+
+		climate, err := s.execute()
+
+		s.Nil(climate)
+		s.Equal(unavailableErr, err)
+	})
+}
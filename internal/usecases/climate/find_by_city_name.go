@@ -2,47 +2,88 @@ package climate
 
 import (
 	"context"
-	"fmt"
-	"net/url"
 
 	"github.com/rs/zerolog"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 
 	"github.com/wellalencarweb/otel-lab-challenge/internal/entities"
-	"github.com/wellalencarweb/otel-lab-challenge/internal/pkg/httpclient"
 )
 
 type FindByCityNameUseCaseInterface interface {
-	Execute(ctx context.Context, city string) (*entities.Climate, error)
+	Execute(ctx context.Context, city string, region string) (*entities.Climate, error)
 }
 
+// FindByCityNameUseCase geocodes the city within its region and reads the weather at its
+// coordinates, as many cities share names across states. When there is no Geocoder or it
+// fails, providers search "city, region" as text instead.
 type FindByCityNameUseCase struct {
-	HttpClient httpclient.HttpClientInterface
-	Logger     zerolog.Logger
-	APIKey     string
+	Provider WeatherProvider
+	Geocoder Geocoder
+	Logger   zerolog.Logger
 }
 
 func NewFindByCityNameUseCase(
-	httpClient httpclient.HttpClientInterface,
+	provider WeatherProvider,
+	geocoder Geocoder,
 	logger zerolog.Logger,
-	apiKey string,
 ) *FindByCityNameUseCase {
 	return &FindByCityNameUseCase{
-		HttpClient: httpClient,
-		Logger:     logger,
-		APIKey:     apiKey,
+		Provider: provider,
+		Geocoder: geocoder,
+		Logger:   logger,
 	}
 }
 
-func (uc *FindByCityNameUseCase) Execute(ctx context.Context, city string) (*entities.Climate, error) {
-	var climate entities.Climate
+func (uc *FindByCityNameUseCase) Execute(ctx context.Context, city string, region string) (*entities.Climate, error) {
+	uc.Logger.Info().Msgf("[FindByCityName] Calling [%s] with city name [%s] and region [%s]", uc.Provider.Name(), city, region)
 
-	uc.Logger.Info().Msgf("[FindByCityName] Calling API with city name [%s]", city)
+	span := trace.SpanFromContext(ctx)
+	query := resolveQuery(ctx, uc.Geocoder, uc.Logger, city, region)
 
-	if err := uc.HttpClient.Get(ctx, fmt.Sprintf("/v1/current.json?key=%s&q=%s&aqi=no", uc.APIKey, url.QueryEscape(city)), &climate); err != nil {
-		return nil, err.Error
+	climate, err := uc.Provider.CurrentWeather(ctx, query)
+	if err != nil {
+		return nil, err
 	}
 
-	uc.Logger.Debug().Msgf("[FindByCityName] Got climate data [%+v]", climate)
+	if query.Coordinates != nil {
+		climate.Location.Latitude = query.Coordinates.Latitude
+		climate.Location.Longitude = query.Coordinates.Longitude
+	}
+
+	span.SetAttributes(weatherProviderKey.String(climate.Provider))
+
+	uc.Logger.Debug().Msgf("[FindByCityName] Got climate data [%+v]", *climate)
+
+	return climate, nil
+}
+
+// resolveQuery geocodes the city within its region, leaving the coordinates out, so providers
+// search by name, when there is no geocoder or it fails.
+func resolveQuery(ctx context.Context, geocoder Geocoder, logger zerolog.Logger, city string, region string) entities.ClimateQuery {
+	span := trace.SpanFromContext(ctx)
+	query := entities.ClimateQuery{City: city, Region: region}
+
+	if geocoder == nil {
+		span.SetAttributes(weatherQueryKey.String("text"))
+		return query
+	}
+
+	place, err := geocoder.Geocode(ctx, query)
+	if err != nil {
+		logger.Warn().Msgf("[Geocoding] Error geocoding city [%s] in region [%s], searching by name: %s", city, region, err)
+		span.AddEvent("geocoding.failed", trace.WithAttributes(attribute.String("error", err.Error())))
+		span.SetAttributes(weatherQueryKey.String("text"))
+
+		return query
+	}
+
+	query.Coordinates = &entities.Coordinates{Latitude: place.Latitude, Longitude: place.Longitude}
+	span.SetAttributes(
+		weatherQueryKey.String("coordinates"),
+		attribute.Float64("geo.latitude", place.Latitude),
+		attribute.Float64("geo.longitude", place.Longitude),
+	)
 
-	return &climate, nil
+	return query
 }
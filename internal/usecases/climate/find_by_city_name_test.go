@@ -2,24 +2,22 @@ package climate
 
 import (
 	"context"
-	"fmt"
-	"net/url"
+	"errors"
 	"testing"
 
 	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/suite"
 
 	"github.com/wellalencarweb/otel-lab-challenge/internal/entities"
-	"github.com/wellalencarweb/otel-lab-challenge/internal/pkg/httpclient"
 	"github.com/wellalencarweb/otel-lab-challenge/internal/pkg/mocks"
 )
 
-const API_KEY = "any-api-key"
-
 type FindByCityNameUseCaseTestSuite struct {
 	suite.Suite
-	HttpClientMock        *mocks.HttpClientMock
-	FindByCityNameUseCase *FindByCityNameUseCase
+	ProviderMock *mocks.WeatherProviderMock
+	GeocoderMock *mocks.GeocoderMock
+	UseCase      *FindByCityNameUseCase
 }
 
 func TestFindByCityNameUseCase(t *testing.T) {
@@ -27,46 +25,58 @@ func TestFindByCityNameUseCase(t *testing.T) {
 }
 
 func (s *FindByCityNameUseCaseTestSuite) SetupTest() {
-	httpClientMock := new(mocks.HttpClientMock)
-
-	s.HttpClientMock = httpClientMock
-	s.FindByCityNameUseCase = NewFindByCityNameUseCase(httpClientMock, zerolog.Nop(), API_KEY)
-}
-
-func (s *FindByCityNameUseCaseTestSuite) clearMocks() {
-	s.HttpClientMock.ExpectedCalls = nil
+	s.ProviderMock = new(mocks.WeatherProviderMock)
+	s.ProviderMock.On("Name").Return("weatherapi")
+	s.GeocoderMock = new(mocks.GeocoderMock)
+	s.UseCase = NewFindByCityNameUseCase(s.ProviderMock, s.GeocoderMock, zerolog.Nop())
 }
 
-func (s *FindByCityNameUseCaseTestSuite) TestFindByCityNameUseCase() {
-	s.Run("should return climate", func() {
-		defer s.clearMocks()
-
-		ctx := context.Background()
-		city := "Rio de Janeiro"
-		endpoint := fmt.Sprintf("/v1/current.json?key=%s&q=%s&aqi=no", API_KEY, url.QueryEscape(city))
+func (s *FindByCityNameUseCaseTestSuite) TestExecute() {
+	ctx := context.Background()
+	textQuery := entities.ClimateQuery{City: "São José", Region: "SC"}
+
+	s.Run("should query by the geocoded coordinates", func() {
+		s.SetupTest()
+		coordinatesQuery := entities.ClimateQuery{
+			City:        "São José",
+			Region:      "SC",
+			Coordinates: &entities.Coordinates{Latitude: -27.6144, Longitude: -48.6275},
+		}
+
+		s.GeocoderMock.On("Geocode", mock.Anything, textQuery).Return(&entities.ClimateLocation{Latitude: -27.6144, Longitude: -48.6275}, nil).Once()
+		s.ProviderMock.On("CurrentWeather", mock.Anything, coordinatesQuery).Return(&entities.Climate{
+			Provider: "weatherapi",
+			Location: entities.ClimateLocation{Latitude: -27.61, Longitude: -48.63},
+		}, nil).Once()
+
+		result, err := s.UseCase.Execute(ctx, "São José", "SC")
+
+		s.Require().NoError(err)
+		s.Equal(-27.6144, result.Location.Latitude)
+		s.Equal(-48.6275, result.Location.Longitude)
+		s.ProviderMock.AssertExpectations(s.T())
+	})
 
-		s.HttpClientMock.On("Get", ctx, endpoint, &entities.Climate{}).Return(nil)
+	s.Run("should search by city and region when geocoding fails", func() {
+		s.SetupTest()
+		s.GeocoderMock.On("Geocode", mock.Anything, textQuery).Return((*entities.ClimateLocation)(nil), errors.New("unavailable")).Once()
+		s.ProviderMock.On("CurrentWeather", mock.Anything, textQuery).Return(&entities.Climate{Provider: "weatherapi"}, nil).Once()
 
-		result, err := s.FindByCityNameUseCase.Execute(ctx, city)
+		result, err := s.UseCase.Execute(ctx, "São José", "SC")
 
-		s.Nil(err)
-		s.NotNil(result)
+		s.Require().NoError(err)
+		s.Equal("weatherapi", result.Provider)
+		s.ProviderMock.AssertExpectations(s.T())
 	})
 
-	s.Run("should return error when http client returns error", func() {
-		defer s.clearMocks()
-
-		ctx := context.Background()
-		city := "Rio de Janeiro"
-		endpoint := fmt.Sprintf("/v1/current.json?key=%s&q=%s&aqi=no", API_KEY, url.QueryEscape(city))
-
-		s.HttpClientMock.On("Get", ctx, endpoint, &entities.Climate{}).Return(&httpclient.HttpClientError{
-			Error: fmt.Errorf("any-error"),
-		})
+	s.Run("should search by city and region without a geocoder", func() {
+		s.SetupTest()
+		s.UseCase.Geocoder = nil
+		s.ProviderMock.On("CurrentWeather", mock.Anything, textQuery).Return(&entities.Climate{Provider: "weatherapi"}, nil).Once()
 
-		result, err := s.FindByCityNameUseCase.Execute(ctx, city)
+		_, err := s.UseCase.Execute(ctx, "São José", "SC")
 
-		s.Error(err)
-		s.Nil(result)
+		s.Require().NoError(err)
+		s.ProviderMock.AssertExpectations(s.T())
 	})
 }
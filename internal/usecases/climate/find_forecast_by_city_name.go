@@ -0,0 +1,63 @@
+package climate
+
+import (
+	"context"
+
+	"github.com/rs/zerolog"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/wellalencarweb/otel-lab-challenge/internal/entities"
+)
+
+type FindForecastByCityNameUseCaseInterface interface {
+	Execute(ctx context.Context, city string, region string, days int) (*entities.Forecast, error)
+}
+
+// FindForecastByCityNameUseCase reads the daily forecast for the next days, locating the city
+// as FindByCityNameUseCase does.
+type FindForecastByCityNameUseCase struct {
+	Provider WeatherProvider
+	Geocoder Geocoder
+	Logger   zerolog.Logger
+}
+
+func NewFindForecastByCityNameUseCase(
+	provider WeatherProvider,
+	geocoder Geocoder,
+	logger zerolog.Logger,
+) *FindForecastByCityNameUseCase {
+	return &FindForecastByCityNameUseCase{
+		Provider: provider,
+		Geocoder: geocoder,
+		Logger:   logger,
+	}
+}
+
+func (uc *FindForecastByCityNameUseCase) Execute(ctx context.Context, city string, region string, days int) (*entities.Forecast, error) {
+	uc.Logger.Info().Msgf("[FindForecastByCityName] Calling [%s] with city name [%s], region [%s] and [%d] days", uc.Provider.Name(), city, region, days)
+
+	span := trace.SpanFromContext(ctx)
+	query := resolveQuery(ctx, uc.Geocoder, uc.Logger, city, region)
+
+	forecast, err := uc.Provider.Forecast(ctx, query, days)
+	if err != nil {
+		return nil, err
+	}
+
+	if query.Coordinates != nil {
+		forecast.Location.Latitude = query.Coordinates.Latitude
+		forecast.Location.Longitude = query.Coordinates.Longitude
+	}
+
+	// providers may answer fewer days than asked for (WeatherAPI's free plan stops at 3), never more
+	if len(forecast.Days) > days {
+		forecast.Days = forecast.Days[:days]
+	}
+
+	span.SetAttributes(weatherProviderKey.String(forecast.Provider), attribute.Int("forecast.days", len(forecast.Days)))
+
+	uc.Logger.Debug().Msgf("[FindForecastByCityName] Got forecast data [%+v]", *forecast)
+
+	return forecast, nil
+}
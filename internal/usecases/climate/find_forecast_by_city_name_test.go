@@ -0,0 +1,57 @@
+package climate
+
+import (
+	"context"
+	"testing"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/wellalencarweb/otel-lab-challenge/internal/entities"
+	"github.com/wellalencarweb/otel-lab-challenge/internal/pkg/mocks"
+)
+
+type FindForecastByCityNameUseCaseTestSuite struct {
+	suite.Suite
+	ProviderMock *mocks.WeatherProviderMock
+	GeocoderMock *mocks.GeocoderMock
+	UseCase      *FindForecastByCityNameUseCase
+}
+
+func TestFindForecastByCityNameUseCase(t *testing.T) {
+	suite.Run(t, new(FindForecastByCityNameUseCaseTestSuite))
+}
+
+func (s *FindForecastByCityNameUseCaseTestSuite) SetupTest() {
+	s.ProviderMock = new(mocks.WeatherProviderMock)
+	s.ProviderMock.On("Name").Return("weatherapi")
+	s.GeocoderMock = new(mocks.GeocoderMock)
+	s.UseCase = NewFindForecastByCityNameUseCase(s.ProviderMock, s.GeocoderMock, zerolog.Nop())
+}
+
+func (s *FindForecastByCityNameUseCaseTestSuite) TestExecute() {
+	textQuery := entities.ClimateQuery{City: "São José", Region: "SC"}
+	coordinatesQuery := entities.ClimateQuery{
+		City:        "São José",
+		Region:      "SC",
+		Coordinates: &entities.Coordinates{Latitude: -27.6144, Longitude: -48.6275},
+	}
+
+	s.GeocoderMock.On("Geocode", mock.Anything, textQuery).Return(&entities.ClimateLocation{Latitude: -27.6144, Longitude: -48.6275}, nil).Once()
+	s.ProviderMock.On("Forecast", mock.Anything, coordinatesQuery, 2).Return(&entities.Forecast{
+		Provider: "weatherapi",
+		Days: []entities.ForecastDay{
+			{Date: "2025-10-17"},
+			{Date: "2025-10-18"},
+			{Date: "2025-10-19"},
+		},
+	}, nil).Once()
+
+	result, err := s.UseCase.Execute(context.Background(), "São José", "SC", 2)
+
+	s.Require().NoError(err)
+	s.Equal(-27.6144, result.Location.Latitude)
+	s.Len(result.Days, 2)
+	s.ProviderMock.AssertExpectations(s.T())
+}
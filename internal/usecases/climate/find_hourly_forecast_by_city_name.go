@@ -0,0 +1,81 @@
+package climate
+
+import (
+	"context"
+	"time"
+	// embeds the time zone database, so locations resolve on images without one
+	_ "time/tzdata"
+
+	"github.com/rs/zerolog"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/wellalencarweb/otel-lab-challenge/internal/entities"
+)
+
+type FindHourlyForecastByCityNameUseCaseInterface interface {
+	Execute(ctx context.Context, city string, region string, hours int) (*entities.Forecast, error)
+}
+
+// FindHourlyForecastByCityNameUseCase reads the hourly forecast from the current hour on, with
+// times localized to the location's time zone. Locations in a zone the tz database does not
+// know are left in UTC.
+type FindHourlyForecastByCityNameUseCase struct {
+	Provider WeatherProvider
+	Geocoder Geocoder
+	Logger   zerolog.Logger
+}
+
+func NewFindHourlyForecastByCityNameUseCase(
+	provider WeatherProvider,
+	geocoder Geocoder,
+	logger zerolog.Logger,
+) *FindHourlyForecastByCityNameUseCase {
+	return &FindHourlyForecastByCityNameUseCase{
+		Provider: provider,
+		Geocoder: geocoder,
+		Logger:   logger,
+	}
+}
+
+func (uc *FindHourlyForecastByCityNameUseCase) Execute(ctx context.Context, city string, region string, hours int) (*entities.Forecast, error) {
+	uc.Logger.Info().Msgf("[FindHourlyForecastByCityName] Calling [%s] with city name [%s], region [%s] and [%d] hours", uc.Provider.Name(), city, region, hours)
+
+	span := trace.SpanFromContext(ctx)
+	query := resolveQuery(ctx, uc.Geocoder, uc.Logger, city, region)
+
+	forecast, err := uc.Provider.HourlyForecast(ctx, query, hours)
+	if err != nil {
+		return nil, err
+	}
+
+	if query.Coordinates != nil {
+		forecast.Location.Latitude = query.Coordinates.Latitude
+		forecast.Location.Longitude = query.Coordinates.Longitude
+	}
+
+	if len(forecast.Hours) > hours {
+		forecast.Hours = forecast.Hours[:hours]
+	}
+
+	location, err := time.LoadLocation(forecast.Location.TimeZone)
+	if err != nil {
+		uc.Logger.Warn().Msgf("[FindHourlyForecastByCityName] Unknown time zone [%s], answering in UTC: %s", forecast.Location.TimeZone, err)
+		location = time.UTC
+		forecast.Location.TimeZone = location.String()
+	}
+
+	for i := range forecast.Hours {
+		forecast.Hours[i].Time = forecast.Hours[i].Time.In(location)
+	}
+
+	span.SetAttributes(
+		weatherProviderKey.String(forecast.Provider),
+		attribute.Int("forecast.hours", len(forecast.Hours)),
+		attribute.String("forecast.tz_id", location.String()),
+	)
+
+	uc.Logger.Debug().Msgf("[FindHourlyForecastByCityName] Got forecast data [%+v]", *forecast)
+
+	return forecast, nil
+}
@@ -0,0 +1,95 @@
+package climate
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/wellalencarweb/otel-lab-challenge/internal/entities"
+	"github.com/wellalencarweb/otel-lab-challenge/internal/pkg/mocks"
+)
+
+type FindHourlyForecastByCityNameUseCaseTestSuite struct {
+	suite.Suite
+	ProviderMock *mocks.WeatherProviderMock
+	UseCase      *FindHourlyForecastByCityNameUseCase
+}
+
+func TestFindHourlyForecastByCityNameUseCase(t *testing.T) {
+	suite.Run(t, new(FindHourlyForecastByCityNameUseCaseTestSuite))
+}
+
+func (s *FindHourlyForecastByCityNameUseCaseTestSuite) SetupTest() {
+	s.ProviderMock = new(mocks.WeatherProviderMock)
+	s.ProviderMock.On("Name").Return("openmeteo")
+	s.UseCase = NewFindHourlyForecastByCityNameUseCase(s.ProviderMock, nil, zerolog.Nop())
+}
+
+func (s *FindHourlyForecastByCityNameUseCaseTestSuite) execute(timeZone string, hours ...time.Time) *entities.Forecast {
+	forecast := &entities.Forecast{
+		Provider: "openmeteo",
+		Location: entities.ClimateLocation{TimeZone: timeZone},
+	}
+	for _, hour := range hours {
+		forecast.Hours = append(forecast.Hours, entities.ForecastHour{Time: hour})
+	}
+
+	s.ProviderMock.On("HourlyForecast", mock.Anything, mock.Anything, len(hours)).Return(forecast, nil).Once()
+
+	result, err := s.UseCase.Execute(context.Background(), "Cidade", "UF", len(hours))
+	s.Require().NoError(err)
+
+	return result
+}
+
+func (s *FindHourlyForecastByCityNameUseCaseTestSuite) TestLocalizesAcrossBrazilianOffsets() {
+	noonUTC := time.Date(2025, 10, 17, 12, 0, 0, 0, time.UTC)
+
+	for timeZone, expected := range map[string]string{
+		"America/Rio_Branco":  "2025-10-17T07:00:00-05:00",
+		"America/Manaus":      "2025-10-17T08:00:00-04:00",
+		"America/Sao_Paulo":   "2025-10-17T09:00:00-03:00",
+		"America/Noronha":     "2025-10-17T10:00:00-02:00",
+		"America/Porto_Velho": "2025-10-17T08:00:00-04:00",
+	} {
+		s.Run(timeZone, func() {
+			s.SetupTest()
+
+			result := s.execute(timeZone, noonUTC)
+
+			s.Equal(expected, result.Hours[0].Time.Format(time.RFC3339))
+		})
+	}
+}
+
+func (s *FindHourlyForecastByCityNameUseCaseTestSuite) TestKeepsOffsetsWithoutDaylightSaving() {
+	// Brazil dropped daylight saving in 2019, so summer hours keep the standard offsets
+	summer := time.Date(2026, 1, 15, 12, 0, 0, 0, time.UTC)
+	winter := time.Date(2026, 7, 15, 12, 0, 0, 0, time.UTC)
+
+	for timeZone, offset := range map[string]string{
+		"America/Sao_Paulo":  "-03:00",
+		"America/Manaus":     "-04:00",
+		"America/Rio_Branco": "-05:00",
+	} {
+		s.Run(timeZone, func() {
+			s.SetupTest()
+
+			result := s.execute(timeZone, summer, winter)
+
+			s.Equal(offset, result.Hours[0].Time.Format("-07:00"))
+			s.Equal(offset, result.Hours[1].Time.Format("-07:00"))
+		})
+	}
+}
+
+func (s *FindHourlyForecastByCityNameUseCaseTestSuite) TestFallsBackToUTCForUnknownZones() {
+	result := s.execute("Mars/Olympus_Mons", time.Date(2025, 10, 17, 12, 0, 0, 0, time.UTC))
+
+	s.Equal("2025-10-17T12:00:00Z", result.Hours[0].Time.Format(time.RFC3339))
+	s.Equal("UTC", result.Location.TimeZone)
+}
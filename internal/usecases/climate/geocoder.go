@@ -0,0 +1,114 @@
+package climate
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/wellalencarweb/otel-lab-challenge/internal/entities"
+	"github.com/wellalencarweb/otel-lab-challenge/internal/pkg/customerrors"
+	"github.com/wellalencarweb/otel-lab-challenge/internal/pkg/httpclient"
+)
+
+// states maps UFs to the state names Open-Meteo's geocoding reports as admin1.
+var states = map[string]string{
+	"AC": "Acre", "AL": "Alagoas", "AP": "Amapá", "AM": "Amazonas", "BA": "Bahia", "CE": "Ceará",
+	"DF": "Distrito Federal", "ES": "Espírito Santo", "GO": "Goiás", "MA": "Maranhão", "MT": "Mato Grosso",
+	"MS": "Mato Grosso do Sul", "MG": "Minas Gerais", "PA": "Pará", "PB": "Paraíba", "PR": "Paraná",
+	"PE": "Pernambuco", "PI": "Piauí", "RJ": "Rio de Janeiro", "RN": "Rio Grande do Norte",
+	"RS": "Rio Grande do Sul", "RO": "Rondônia", "RR": "Roraima", "SC": "Santa Catarina",
+	"SP": "São Paulo", "SE": "Sergipe", "TO": "Tocantins",
+}
+
+type openMeteoPlace struct {
+	Name      string  `json:"name"`
+	Latitude  float64 `json:"latitude"`
+	Longitude float64 `json:"longitude"`
+	Timezone  string  `json:"timezone"`
+	Country   string  `json:"country"`
+	Admin1    string  `json:"admin1"`
+}
+
+type openMeteoGeocodingResponse struct {
+	Results []openMeteoPlace `json:"results"`
+}
+
+// Geocoder resolves a city within its state to a place, coordinates included.
+type Geocoder interface {
+	Geocode(ctx context.Context, query entities.ClimateQuery) (*entities.ClimateLocation, error)
+}
+
+type OpenMeteoGeocoder struct {
+	HttpClient httpclient.HttpClientInterface
+}
+
+func NewOpenMeteoGeocoder(httpClient httpclient.HttpClientInterface) *OpenMeteoGeocoder {
+	return &OpenMeteoGeocoder{
+		HttpClient: httpClient,
+	}
+}
+
+// Geocode searches Brazilian places by name within the query's state. Only a query without a
+// known state falls back to the best ranked place, as a same-name city elsewhere would be wrong.
+func (g *OpenMeteoGeocoder) Geocode(ctx context.Context, query entities.ClimateQuery) (*entities.ClimateLocation, error) {
+	tags := map[string]interface{}{
+		"city":     query.City,
+		"region":   query.Region,
+		"provider": "openmeteo-geocoding",
+	}
+
+	var response openMeteoGeocodingResponse
+
+	if err := g.HttpClient.Get(
+		ctx,
+		"/v1/search",
+		&response,
+		httpclient.WithQuery("name", query.City),
+		httpclient.WithQuery("count", "10"),
+		httpclient.WithQuery("language", "pt"),
+		httpclient.WithQuery("countryCode", "BR"),
+	); err != nil {
+		return nil, err.AsCustomError("can not find climate for city", "Unknown error geocoding city", tags)
+	}
+
+	if len(response.Results) == 0 {
+		return nil, &customerrors.NotFoundError{
+			Err:     errors.New("no matching location found"),
+			Message: "can not find climate for city",
+			Tags:    tags,
+		}
+	}
+
+	place, ok := matchState(response.Results, query.Region)
+	if !ok {
+		return nil, &customerrors.NotFoundError{
+			Err:     errors.New("no matching location found in state"),
+			Message: "can not find climate for city",
+			Tags:    tags,
+		}
+	}
+
+	return &entities.ClimateLocation{
+		Name:      place.Name,
+		Region:    place.Admin1,
+		Country:   place.Country,
+		Latitude:  place.Latitude,
+		Longitude: place.Longitude,
+		TimeZone:  place.Timezone,
+	}, nil
+}
+
+func matchState(places []openMeteoPlace, region string) (openMeteoPlace, bool) {
+	state, ok := states[strings.ToUpper(region)]
+	if !ok {
+		return places[0], true
+	}
+
+	for _, place := range places {
+		if strings.EqualFold(place.Admin1, state) {
+			return place, true
+		}
+	}
+
+	return openMeteoPlace{}, false
+}
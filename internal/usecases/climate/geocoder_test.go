@@ -0,0 +1,97 @@
+package climate
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/wellalencarweb/otel-lab-challenge/internal/entities"
+	"github.com/wellalencarweb/otel-lab-challenge/internal/pkg/cache"
+	"github.com/wellalencarweb/otel-lab-challenge/internal/pkg/customerrors"
+	"github.com/wellalencarweb/otel-lab-challenge/internal/pkg/mocks"
+	"github.com/wellalencarweb/otel-lab-challenge/internal/pkg/mocks/cassettetest"
+)
+
+type GeocoderTestSuite struct {
+	suite.Suite
+}
+
+func TestGeocoder(t *testing.T) {
+	suite.Run(t, new(GeocoderTestSuite))
+}
+
+func (s *GeocoderTestSuite) TestOpenMeteoGeocoderWithCassette() {
+	geocoder := NewOpenMeteoGeocoder(
+		cassettetest.NewHttpClient(s.T(), "openmeteo-geocoding", "https://geocoding-api.open-meteo.com", "openmeteo-geocoding.json"),
+	)
+
+	s.Run("should prefer the match within the query's state", func() {
+		place, err := geocoder.Geocode(context.Background(), entities.ClimateQuery{City: "São José", Region: "SC"})
+
+		s.Require().NoError(err)
+		s.Equal("São José", place.Name)
+		s.Equal("Santa Catarina", place.Region)
+		s.Equal(-27.61444, place.Latitude)
+	})
+
+	s.Run("should fall back to the best ranked match for an unknown state", func() {
+		place, err := geocoder.Geocode(context.Background(), entities.ClimateQuery{City: "São José"})
+
+		s.Require().NoError(err)
+		s.Equal("São José dos Campos", place.Name)
+	})
+
+	s.Run("should return not found when the only matches are in other states", func() {
+		place, err := geocoder.Geocode(context.Background(), entities.ClimateQuery{City: "São José", Region: "RS"})
+
+		s.Nil(place)
+		s.IsType(&customerrors.NotFoundError{}, err)
+	})
+
+	s.Run("should return not found when nothing matches", func() {
+		place, err := geocoder.Geocode(context.Background(), entities.ClimateQuery{City: "Atlantis"})
+
+		s.Nil(place)
+		s.IsType(&customerrors.NotFoundError{}, err)
+	})
+}
+
+func (s *GeocoderTestSuite) TestCachedGeocoder() {
+	ctx := context.Background()
+	next := new(mocks.GeocoderMock)
+	geocoder := NewCachedGeocoder(next, cache.NewLRU[entities.ClimateLocation](10), time.Hour)
+
+	s.Run("should geocode a city once regardless of case and accents", func() {
+		next.On("Geocode", mock.Anything, entities.ClimateQuery{City: "São José", Region: "SC"}).
+			Return(&entities.ClimateLocation{Name: "São José", Latitude: -27.6144, Longitude: -48.6275}, nil).Once()
+
+		place, err := geocoder.Geocode(ctx, entities.ClimateQuery{City: "São José", Region: "SC"})
+		s.Require().NoError(err)
+		s.Equal(-27.6144, place.Latitude)
+
+		place, err = geocoder.Geocode(ctx, entities.ClimateQuery{City: "sao jose", Region: "sc"})
+		s.Require().NoError(err)
+		s.Equal(-27.6144, place.Latitude)
+
+		next.AssertExpectations(s.T())
+	})
+
+	s.Run("should not cache failures", func() {
+		query := entities.ClimateQuery{City: "Rio de Janeiro", Region: "RJ"}
+		next.On("Geocode", mock.Anything, query).Return((*entities.ClimateLocation)(nil), errors.New("unavailable")).Once()
+		next.On("Geocode", mock.Anything, query).Return(&entities.ClimateLocation{Latitude: -22.9068}, nil).Once()
+
+		_, err := geocoder.Geocode(ctx, query)
+		s.Error(err)
+
+		place, err := geocoder.Geocode(ctx, query)
+		s.Require().NoError(err)
+		s.Equal(-22.9068, place.Latitude)
+
+		next.AssertExpectations(s.T())
+	})
+}
@@ -0,0 +1,260 @@
+package climate
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/wellalencarweb/otel-lab-challenge/internal/entities"
+	"github.com/wellalencarweb/otel-lab-challenge/internal/pkg/httpclient"
+)
+
+const (
+	openMeteoCurrentFields = "temperature_2m,relative_humidity_2m,apparent_temperature,is_day,precipitation,weather_code,pressure_msl,wind_speed_10m,wind_direction_10m,uv_index"
+	openMeteoDailyFields   = "temperature_2m_max,temperature_2m_min,temperature_2m_mean,weather_code"
+	openMeteoHourlyFields  = "temperature_2m,weather_code"
+)
+
+// weatherCodes describes the WMO weather interpretation codes Open-Meteo reports.
+var weatherCodes = map[int]string{
+	0: "Clear sky", 1: "Mainly clear", 2: "Partly cloudy", 3: "Overcast", 45: "Fog", 48: "Depositing rime fog",
+	51: "Light drizzle", 53: "Moderate drizzle", 55: "Dense drizzle", 56: "Light freezing drizzle",
+	57: "Dense freezing drizzle", 61: "Slight rain", 63: "Moderate rain", 65: "Heavy rain",
+	66: "Light freezing rain", 67: "Heavy freezing rain", 71: "Slight snow fall", 73: "Moderate snow fall",
+	75: "Heavy snow fall", 77: "Snow grains", 80: "Slight rain showers", 81: "Moderate rain showers",
+	82: "Violent rain showers", 85: "Slight snow showers", 86: "Heavy snow showers", 95: "Thunderstorm",
+	96: "Thunderstorm with slight hail", 99: "Thunderstorm with heavy hail",
+}
+
+type openMeteoForecastResponse struct {
+	Timezone string `json:"timezone"`
+	Current  struct {
+		Time                int64   `json:"time"`
+		Temperature2m       float64 `json:"temperature_2m"`
+		RelativeHumidity2m  int     `json:"relative_humidity_2m"`
+		ApparentTemperature float64 `json:"apparent_temperature"`
+		IsDay               int     `json:"is_day"`
+		Precipitation       float64 `json:"precipitation"`
+		WeatherCode         int     `json:"weather_code"`
+		PressureMsl         float64 `json:"pressure_msl"`
+		WindSpeed10m        float64 `json:"wind_speed_10m"`
+		WindDirection10m    int     `json:"wind_direction_10m"`
+		UvIndex             float64 `json:"uv_index"`
+	} `json:"current"`
+	Daily struct {
+		Time              []string  `json:"time"`
+		Temperature2mMax  []float64 `json:"temperature_2m_max"`
+		Temperature2mMin  []float64 `json:"temperature_2m_min"`
+		Temperature2mMean []float64 `json:"temperature_2m_mean"`
+		WeatherCode       []int     `json:"weather_code"`
+	} `json:"daily"`
+	Hourly struct {
+		Time          []int64   `json:"time"`
+		Temperature2m []float64 `json:"temperature_2m"`
+		WeatherCode   []int     `json:"weather_code"`
+	} `json:"hourly"`
+}
+
+// OpenMeteoProvider needs no API key. Open-Meteo forecasts by coordinates, so a query without
+// them is geocoded first.
+type OpenMeteoProvider struct {
+	HttpClient httpclient.HttpClientInterface
+	Geocoder   Geocoder
+}
+
+func NewOpenMeteoProvider(httpClient httpclient.HttpClientInterface, geocoder Geocoder) *OpenMeteoProvider {
+	return &OpenMeteoProvider{
+		HttpClient: httpClient,
+		Geocoder:   geocoder,
+	}
+}
+
+func (p *OpenMeteoProvider) Name() string {
+	return "openmeteo"
+}
+
+func (p *OpenMeteoProvider) CurrentWeather(ctx context.Context, query entities.ClimateQuery) (*entities.Climate, error) {
+	tags := map[string]interface{}{
+		"city":     query.City,
+		"region":   query.Region,
+		"provider": p.Name(),
+	}
+
+	place, err := p.locate(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+
+	var response openMeteoForecastResponse
+
+	if err := p.HttpClient.Get(
+		ctx,
+		"/v1/forecast",
+		&response,
+		httpclient.WithQuery("latitude", formatCoordinate(place.Latitude)),
+		httpclient.WithQuery("longitude", formatCoordinate(place.Longitude)),
+		httpclient.WithQuery("current", openMeteoCurrentFields),
+		httpclient.WithQuery("timezone", "auto"),
+		httpclient.WithQuery("timeformat", "unixtime"),
+	); err != nil {
+		return nil, err.AsCustomError("can not find climate for city", "Unknown error getting climate", tags)
+	}
+
+	climate := &entities.Climate{
+		Provider: p.Name(),
+		Location: *place,
+		Current: entities.ClimateData{
+			TempC:      response.Current.Temperature2m,
+			FeelsLikeC: response.Current.ApparentTemperature,
+			Humidity:   response.Current.RelativeHumidity2m,
+			WindKph:    response.Current.WindSpeed10m,
+			WindDegree: response.Current.WindDirection10m,
+			PressureMb: response.Current.PressureMsl,
+			PrecipMm:   response.Current.Precipitation,
+			Uv:         response.Current.UvIndex,
+			Condition:  weatherCodes[response.Current.WeatherCode],
+			IsDay:      response.Current.IsDay == 1,
+		},
+	}
+
+	climate.Location.TimeZone = response.Timezone
+
+	if response.Current.Time > 0 {
+		climate.Current.ObservedAt = time.Unix(response.Current.Time, 0)
+	}
+
+	return climate, nil
+}
+
+func (p *OpenMeteoProvider) Forecast(ctx context.Context, query entities.ClimateQuery, days int) (*entities.Forecast, error) {
+	tags := map[string]interface{}{
+		"city":     query.City,
+		"region":   query.Region,
+		"provider": p.Name(),
+	}
+
+	place, err := p.locate(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+
+	var response openMeteoForecastResponse
+
+	if err := p.HttpClient.Get(
+		ctx,
+		"/v1/forecast",
+		&response,
+		httpclient.WithQuery("latitude", formatCoordinate(place.Latitude)),
+		httpclient.WithQuery("longitude", formatCoordinate(place.Longitude)),
+		httpclient.WithQuery("daily", openMeteoDailyFields),
+		httpclient.WithQuery("forecast_days", strconv.Itoa(days)),
+		httpclient.WithQuery("timezone", "auto"),
+	); err != nil {
+		return nil, err.AsCustomError("can not find forecast for city", "Unknown error getting forecast", tags)
+	}
+
+	forecast := &entities.Forecast{
+		Provider: p.Name(),
+		Location: *place,
+		Days:     make([]entities.ForecastDay, 0, len(response.Daily.Time)),
+	}
+
+	forecast.Location.TimeZone = response.Timezone
+
+	daily := response.Daily
+	for i, date := range daily.Time {
+		// Open-Meteo answers with parallel arrays; a short one means a malformed answer
+		if i >= len(daily.Temperature2mMax) || i >= len(daily.Temperature2mMin) || i >= len(daily.Temperature2mMean) || i >= len(daily.WeatherCode) {
+			break
+		}
+
+		forecast.Days = append(forecast.Days, entities.ForecastDay{
+			Date:      date,
+			MinTempC:  daily.Temperature2mMin[i],
+			MaxTempC:  daily.Temperature2mMax[i],
+			AvgTempC:  daily.Temperature2mMean[i],
+			Condition: weatherCodes[daily.WeatherCode[i]],
+		})
+	}
+
+	return forecast, nil
+}
+
+// HourlyForecast relies on forecast_hours, which Open-Meteo counts from the current hour.
+func (p *OpenMeteoProvider) HourlyForecast(ctx context.Context, query entities.ClimateQuery, hours int) (*entities.Forecast, error) {
+	tags := map[string]interface{}{
+		"city":     query.City,
+		"region":   query.Region,
+		"provider": p.Name(),
+	}
+
+	place, err := p.locate(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+
+	var response openMeteoForecastResponse
+
+	if err := p.HttpClient.Get(
+		ctx,
+		"/v1/forecast",
+		&response,
+		httpclient.WithQuery("latitude", formatCoordinate(place.Latitude)),
+		httpclient.WithQuery("longitude", formatCoordinate(place.Longitude)),
+		httpclient.WithQuery("hourly", openMeteoHourlyFields),
+		httpclient.WithQuery("forecast_hours", strconv.Itoa(hours)),
+		httpclient.WithQuery("timezone", "auto"),
+		httpclient.WithQuery("timeformat", "unixtime"),
+	); err != nil {
+		return nil, err.AsCustomError("can not find forecast for city", "Unknown error getting forecast", tags)
+	}
+
+	forecast := &entities.Forecast{
+		Provider: p.Name(),
+		Location: *place,
+		Hours:    make([]entities.ForecastHour, 0, len(response.Hourly.Time)),
+	}
+
+	forecast.Location.TimeZone = response.Timezone
+
+	hourly := response.Hourly
+	for i, at := range hourly.Time {
+		if i >= len(hourly.Temperature2m) || i >= len(hourly.WeatherCode) {
+			break
+		}
+
+		forecast.Hours = append(forecast.Hours, entities.ForecastHour{
+			Time:      time.Unix(at, 0).UTC(),
+			TempC:     hourly.Temperature2m[i],
+			Condition: weatherCodes[hourly.WeatherCode[i]],
+		})
+	}
+
+	return forecast, nil
+}
+
+// locate trusts the query's coordinates when they are resolved, and geocodes the city otherwise.
+func (p *OpenMeteoProvider) locate(ctx context.Context, query entities.ClimateQuery) (*entities.ClimateLocation, error) {
+	if query.Coordinates == nil {
+		return p.Geocoder.Geocode(ctx, query)
+	}
+
+	region := query.Region
+	if state, ok := states[strings.ToUpper(region)]; ok {
+		region = state
+	}
+
+	return &entities.ClimateLocation{
+		Name:      query.City,
+		Region:    region,
+		Country:   "Brasil",
+		Latitude:  query.Coordinates.Latitude,
+		Longitude: query.Coordinates.Longitude,
+	}, nil
+}
+
+func formatCoordinate(value float64) string {
+	return fmt.Sprintf("%.4f", value)
+}
@@ -0,0 +1,96 @@
+package climate
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/wellalencarweb/otel-lab-challenge/internal/entities"
+	"github.com/wellalencarweb/otel-lab-challenge/internal/pkg/customerrors"
+	"github.com/wellalencarweb/otel-lab-challenge/internal/pkg/mocks/cassettetest"
+)
+
+type OpenMeteoProviderTestSuite struct {
+	suite.Suite
+}
+
+func TestOpenMeteoProvider(t *testing.T) {
+	suite.Run(t, new(OpenMeteoProviderTestSuite))
+}
+
+func (s *OpenMeteoProviderTestSuite) TestCurrentWeatherWithCassette() {
+	provider := NewOpenMeteoProvider(
+		cassettetest.NewHttpClient(s.T(), "openmeteo", "https://api.open-meteo.com", "openmeteo.json"),
+		NewOpenMeteoGeocoder(cassettetest.NewHttpClient(s.T(), "openmeteo-geocoding", "https://geocoding-api.open-meteo.com", "openmeteo-geocoding.json")),
+	)
+
+	s.Run("should geocode the city within its state and decode weather", func() {
+		result, err := provider.CurrentWeather(context.Background(), entities.ClimateQuery{City: "São José", Region: "SC"})
+
+		s.Require().NoError(err)
+		s.Equal("openmeteo", result.Provider)
+		s.Equal("São José", result.Location.Name)
+		s.Equal("Santa Catarina", result.Location.Region)
+		s.Equal("America/Sao_Paulo", result.Location.TimeZone)
+		s.Equal(22.4, result.Current.TempC)
+		s.Equal(78, result.Current.Humidity)
+		s.Equal("Slight rain", result.Current.Condition)
+		s.Equal(4.3, result.Current.Uv)
+		s.Equal(int64(1760720400), result.Current.ObservedAt.Unix())
+	})
+
+	s.Run("should skip geocoding when the coordinates are resolved", func() {
+		result, err := provider.CurrentWeather(context.Background(), entities.ClimateQuery{
+			City:        "São José",
+			Region:      "SC",
+			Coordinates: &entities.Coordinates{Latitude: -27.6144, Longitude: -48.6275},
+		})
+
+		s.Require().NoError(err)
+		s.Equal("São José", result.Location.Name)
+		s.Equal("Santa Catarina", result.Location.Region)
+		s.Equal(-27.6144, result.Location.Latitude)
+		s.Equal(22.4, result.Current.TempC)
+	})
+
+	s.Run("should decode the daily forecast", func() {
+		result, err := provider.Forecast(context.Background(), entities.ClimateQuery{
+			City:        "São José",
+			Region:      "SC",
+			Coordinates: &entities.Coordinates{Latitude: -27.6144, Longitude: -48.6275},
+		}, 2)
+
+		s.Require().NoError(err)
+		s.Equal("openmeteo", result.Provider)
+		s.Equal("America/Sao_Paulo", result.Location.TimeZone)
+		s.Equal([]entities.ForecastDay{
+			{Date: "2025-10-17", MinTempC: 17.8, MaxTempC: 24.1, AvgTempC: 20.6, Condition: "Slight rain"},
+			{Date: "2025-10-18", MinTempC: 16.2, MaxTempC: 21.7, AvgTempC: 18.9, Condition: "Overcast"},
+		}, result.Days)
+	})
+
+	s.Run("should decode the hourly forecast", func() {
+		result, err := provider.HourlyForecast(context.Background(), entities.ClimateQuery{
+			City:        "São José",
+			Region:      "SC",
+			Coordinates: &entities.Coordinates{Latitude: -27.6144, Longitude: -48.6275},
+		}, 2)
+
+		s.Require().NoError(err)
+		s.Equal("openmeteo", result.Provider)
+		s.Equal("America/Sao_Paulo", result.Location.TimeZone)
+		s.Equal([]entities.ForecastHour{
+			{Time: time.Unix(1760720400, 0).UTC(), TempC: 22.4, Condition: "Slight rain"},
+			{Time: time.Unix(1760724000, 0).UTC(), TempC: 22.9, Condition: "Overcast"},
+		}, result.Hours)
+	})
+
+	s.Run("should return not found when the city can not be geocoded", func() {
+		result, err := provider.CurrentWeather(context.Background(), entities.ClimateQuery{City: "Atlantis"})
+
+		s.Nil(result)
+		s.IsType(&customerrors.NotFoundError{}, err)
+	})
+}
@@ -0,0 +1,115 @@
+package climate
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/rs/zerolog"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/wellalencarweb/otel-lab-challenge/internal/entities"
+	"github.com/wellalencarweb/otel-lab-challenge/internal/pkg/customerrors"
+)
+
+const (
+	weatherProviderKey = attribute.Key("weather.provider")
+	weatherQueryKey    = attribute.Key("weather.query")
+)
+
+// WeatherProvider fetches the current weather and the daily and hourly forecasts from one
+// upstream, translating its answers into provider-neutral entities tagged with the provider name.
+type WeatherProvider interface {
+	Name() string
+	CurrentWeather(ctx context.Context, query entities.ClimateQuery) (*entities.Climate, error)
+	Forecast(ctx context.Context, query entities.ClimateQuery, days int) (*entities.Forecast, error)
+	HourlyForecast(ctx context.Context, query entities.ClimateQuery, hours int) (*entities.Forecast, error)
+}
+
+type ChainedProvider struct {
+	Provider WeatherProvider
+	Timeout  time.Duration
+}
+
+// WeatherProviderChain tries its providers in order, each bounded by its own timeout, until
+// one answers. As with locations, a not found answer is final and only outages fall back.
+type WeatherProviderChain struct {
+	Providers []ChainedProvider
+	Logger    zerolog.Logger
+}
+
+func NewWeatherProviderChain(logger zerolog.Logger, providers ...ChainedProvider) *WeatherProviderChain {
+	return &WeatherProviderChain{
+		Providers: providers,
+		Logger:    logger,
+	}
+}
+
+func (c *WeatherProviderChain) Name() string {
+	return "chain"
+}
+
+func (c *WeatherProviderChain) CurrentWeather(ctx context.Context, query entities.ClimateQuery) (*entities.Climate, error) {
+	return firstAnswer(ctx, c, query, func(ctx context.Context, provider WeatherProvider) (*entities.Climate, error) {
+		return provider.CurrentWeather(ctx, query)
+	})
+}
+
+func (c *WeatherProviderChain) Forecast(ctx context.Context, query entities.ClimateQuery, days int) (*entities.Forecast, error) {
+	return firstAnswer(ctx, c, query, func(ctx context.Context, provider WeatherProvider) (*entities.Forecast, error) {
+		return provider.Forecast(ctx, query, days)
+	})
+}
+
+func (c *WeatherProviderChain) HourlyForecast(ctx context.Context, query entities.ClimateQuery, hours int) (*entities.Forecast, error) {
+	return firstAnswer(ctx, c, query, func(ctx context.Context, provider WeatherProvider) (*entities.Forecast, error) {
+		return provider.HourlyForecast(ctx, query, hours)
+	})
+}
+
+func firstAnswer[T any](
+	ctx context.Context,
+	c *WeatherProviderChain,
+	query entities.ClimateQuery,
+	fetch func(ctx context.Context, provider WeatherProvider) (*T, error),
+) (*T, error) {
+	span := trace.SpanFromContext(ctx)
+
+	err := errors.New("no weather provider configured")
+
+	for i, chained := range c.Providers {
+		var answer *T
+		answer, err = attempt(ctx, chained, fetch)
+
+		var notFoundErr *customerrors.NotFoundError
+		if err == nil || errors.As(err, &notFoundErr) || ctx.Err() != nil {
+			span.SetAttributes(attribute.Int("weather.provider.attempts", i+1))
+			return answer, err
+		}
+
+		span.AddEvent("weather.provider.failed", trace.WithAttributes(
+			weatherProviderKey.String(chained.Provider.Name()),
+			attribute.String("error", err.Error()),
+		))
+		c.Logger.Warn().Msgf("[WeatherProviderChain] Provider [%s] failed for city [%s]: %s", chained.Provider.Name(), query.City, err)
+	}
+
+	span.SetAttributes(attribute.Int("weather.provider.attempts", len(c.Providers)))
+
+	return nil, err
+}
+
+func attempt[T any](
+	ctx context.Context,
+	chained ChainedProvider,
+	fetch func(ctx context.Context, provider WeatherProvider) (*T, error),
+) (*T, error) {
+	if chained.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, chained.Timeout)
+		defer cancel()
+	}
+
+	return fetch(ctx, chained.Provider)
+}
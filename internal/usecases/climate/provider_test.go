@@ -0,0 +1,123 @@
+package climate
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+
+	"github.com/wellalencarweb/otel-lab-challenge/internal/entities"
+	"github.com/wellalencarweb/otel-lab-challenge/internal/pkg/customerrors"
+	"github.com/wellalencarweb/otel-lab-challenge/internal/pkg/mocks"
+)
+
+type WeatherProviderChainTestSuite struct {
+	suite.Suite
+	Primary   *mocks.WeatherProviderMock
+	Secondary *mocks.WeatherProviderMock
+	Recorder  *tracetest.SpanRecorder
+	UseCase   *FindByCityNameUseCase
+}
+
+func TestWeatherProviderChain(t *testing.T) {
+	suite.Run(t, new(WeatherProviderChainTestSuite))
+}
+
+func (s *WeatherProviderChainTestSuite) SetupTest() {
+	s.Primary = new(mocks.WeatherProviderMock)
+	s.Primary.On("Name").Return("weatherapi")
+	s.Secondary = new(mocks.WeatherProviderMock)
+	s.Secondary.On("Name").Return("openmeteo")
+
+	chain := NewWeatherProviderChain(zerolog.Nop(),
+		ChainedProvider{Provider: s.Primary},
+		ChainedProvider{Provider: s.Secondary},
+	)
+
+	s.Recorder = tracetest.NewSpanRecorder()
+	s.UseCase = NewFindByCityNameUseCase(chain, nil, zerolog.Nop())
+}
+
+func (s *WeatherProviderChainTestSuite) execute(city string, region string) (*entities.Climate, map[string]interface{}, error) {
+	tracer := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(s.Recorder)).Tracer("climate-test")
+	ctx, span := tracer.Start(context.Background(), "find-climate-by-city-name")
+
+	climate, err := s.UseCase.Execute(ctx, city, region)
+	span.End()
+
+	attrs := make(map[string]interface{})
+	for _, attr := range s.Recorder.Ended()[0].Attributes() {
+		attrs[string(attr.Key)] = attr.Value.AsInterface()
+	}
+
+	return climate, attrs, err
+}
+
+func (s *WeatherProviderChainTestSuite) TestCurrentWeather() {
+	query := entities.ClimateQuery{City: "Rio de Janeiro", Region: "RJ"}
+
+	s.Run("should answer from the first provider", func() {
+		s.SetupTest()
+		s.Primary.On("CurrentWeather", mock.Anything, query).Return(&entities.Climate{Provider: "weatherapi"}, nil).Once()
+
+		climate, attrs, err := s.execute("Rio de Janeiro", "RJ")
+
+		s.Nil(err)
+		s.Equal("weatherapi", climate.Provider)
+		s.Equal("weatherapi", attrs["weather.provider"])
+		s.Equal(int64(1), attrs["weather.provider.attempts"])
+		s.Secondary.AssertNotCalled(s.T(), "CurrentWeather", mock.Anything, mock.Anything)
+	})
+
+	s.Run("should fall back when a provider is unavailable", func() {
+		s.SetupTest()
+		s.Primary.On("CurrentWeather", mock.Anything, query).Return((*entities.Climate)(nil), &customerrors.TooManyRequestsError{
+			Err:     errors.New("rate limited"),
+			Message: "weatherapi rate limit exceeded",
+		}).Once()
+		s.Secondary.On("CurrentWeather", mock.Anything, query).Return(&entities.Climate{Provider: "openmeteo"}, nil).Once()
+
+		climate, attrs, err := s.execute("Rio de Janeiro", "RJ")
+
+		s.Nil(err)
+		s.Equal("openmeteo", climate.Provider)
+		s.Equal("openmeteo", attrs["weather.provider"])
+		s.Equal(int64(2), attrs["weather.provider.attempts"])
+		s.Equal("weather.provider.failed", s.Recorder.Ended()[0].Events()[0].Name)
+	})
+
+	s.Run("should not fall back when the city is not found", func() {
+		s.SetupTest()
+		notFoundErr := &customerrors.NotFoundError{Message: "can not find climate for city"}
+		s.Primary.On("CurrentWeather", mock.Anything, query).Return((*entities.Climate)(nil), notFoundErr).Once()
+
+		climate, _, err := s.execute("Rio de Janeiro", "RJ")
+
+		s.Nil(climate)
+		s.Equal(notFoundErr, err)
+		s.Secondary.AssertNotCalled(s.T(), "CurrentWeather", mock.Anything, mock.Anything)
+	})
+}
+
+func (s *WeatherProviderChainTestSuite) TestForecast() {
+	query := entities.ClimateQuery{City: "Rio de Janeiro", Region: "RJ"}
+
+	s.Run("should fall back when a provider is unavailable", func() {
+		s.SetupTest()
+		s.Primary.On("Forecast", mock.Anything, query, 3).Return((*entities.Forecast)(nil), &customerrors.ServiceUnavailableError{
+			Err: errors.New("weatherapi is unavailable"),
+		}).Once()
+		s.Secondary.On("Forecast", mock.Anything, query, 3).Return(&entities.Forecast{Provider: "openmeteo"}, nil).Once()
+
+		chain := NewWeatherProviderChain(zerolog.Nop(), ChainedProvider{Provider: s.Primary}, ChainedProvider{Provider: s.Secondary})
+		forecast, err := chain.Forecast(context.Background(), query, 3)
+
+		s.Nil(err)
+		s.Equal("openmeteo", forecast.Provider)
+	})
+}
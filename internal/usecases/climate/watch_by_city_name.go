@@ -0,0 +1,269 @@
+package climate
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/wellalencarweb/otel-lab-challenge/internal/entities"
+	"github.com/wellalencarweb/otel-lab-challenge/internal/pkg/customerrors"
+)
+
+const defaultWatchInterval = time.Minute
+
+var (
+	ErrTooManySubscribers = errors.New("too many subscribers")
+	ErrWatchClosed        = errors.New("climate watch closed")
+)
+
+// WatchEvent carries either a new reading or the error of the poll that failed.
+type WatchEvent struct {
+	Climate *entities.Climate
+	Err     error
+}
+
+type WatchSettings struct {
+	Interval       time.Duration
+	MaxSubscribers int
+}
+
+type WatchByCityNameUseCaseInterface interface {
+	Subscribe(ctx context.Context, city string, region string) (<-chan WatchEvent, error)
+}
+
+// WatchByCityNameUseCase polls the weather of each watched city every Interval, once for all
+// of its subscribers, and pushes a reading only when its observation time changes; errors are
+// pushed once until a poll succeeds again. A city is polled while it has subscribers, and at
+// most MaxSubscribers are served at once. Each poll span links to the spans of the requests
+// subscribed at the time, as it runs outside of any of them.
+type WatchByCityNameUseCase struct {
+	Next     FindByCityNameUseCaseInterface
+	Settings WatchSettings
+	Logger   zerolog.Logger
+	Tracer   trace.Tracer
+
+	mu          sync.Mutex
+	watches     map[string]*watch
+	subscribers int
+	closed      bool
+}
+
+type watch struct {
+	city        string
+	region      string
+	cancel      context.CancelFunc
+	subscribers map[chan WatchEvent]trace.SpanContext
+	last        *WatchEvent
+}
+
+func NewWatchByCityNameUseCase(
+	next FindByCityNameUseCaseInterface,
+	settings WatchSettings,
+	logger zerolog.Logger,
+	tracer trace.Tracer,
+) *WatchByCityNameUseCase {
+	if settings.Interval <= 0 {
+		settings.Interval = defaultWatchInterval
+	}
+
+	return &WatchByCityNameUseCase{
+		Next:     next,
+		Settings: settings,
+		Logger:   logger,
+		Tracer:   tracer,
+		watches:  make(map[string]*watch),
+	}
+}
+
+// Subscribe returns the events of the city until ctx is done, when the channel is closed. The
+// last known event, if any, is delivered right away. Events are not queued: a subscriber that
+// falls behind only gets the latest one.
+func (uc *WatchByCityNameUseCase) Subscribe(ctx context.Context, city string, region string) (<-chan WatchEvent, error) {
+	uc.mu.Lock()
+	defer uc.mu.Unlock()
+
+	if uc.closed {
+		return nil, &customerrors.ServiceUnavailableError{Err: ErrWatchClosed, Message: "server is shutting down"}
+	}
+
+	if uc.Settings.MaxSubscribers > 0 && uc.subscribers >= uc.Settings.MaxSubscribers {
+		return nil, &customerrors.ServiceUnavailableError{Err: ErrTooManySubscribers, Message: "too many subscribers"}
+	}
+
+	key := CacheKey(city, region)
+
+	w, ok := uc.watches[key]
+	if !ok {
+		pollCtx, cancel := context.WithCancel(context.Background())
+
+		w = &watch{
+			city:        city,
+			region:      region,
+			cancel:      cancel,
+			subscribers: make(map[chan WatchEvent]trace.SpanContext),
+		}
+		uc.watches[key] = w
+
+		go uc.poll(pollCtx, w)
+	}
+
+	events := make(chan WatchEvent, 1)
+	w.subscribers[events] = trace.SpanContextFromContext(ctx)
+	uc.subscribers++
+
+	if w.last != nil {
+		events <- *w.last
+	}
+
+	trace.SpanFromContext(ctx).SetAttributes(
+		attribute.Bool("stream.shared", ok),
+		attribute.Int("stream.subscribers", uc.subscribers),
+	)
+
+	go func() {
+		<-ctx.Done()
+		uc.unsubscribe(key, w, events)
+	}()
+
+	return events, nil
+}
+
+// Close stops every poll and closes every subscription; later subscriptions are refused.
+func (uc *WatchByCityNameUseCase) Close() error {
+	uc.mu.Lock()
+	defer uc.mu.Unlock()
+
+	uc.closed = true
+
+	for key, w := range uc.watches {
+		w.cancel()
+
+		for events := range w.subscribers {
+			close(events)
+		}
+
+		uc.subscribers -= len(w.subscribers)
+		w.subscribers = nil
+
+		delete(uc.watches, key)
+	}
+
+	return nil
+}
+
+func (uc *WatchByCityNameUseCase) unsubscribe(key string, w *watch, events chan WatchEvent) {
+	uc.mu.Lock()
+	defer uc.mu.Unlock()
+
+	if _, ok := w.subscribers[events]; !ok {
+		return
+	}
+
+	delete(w.subscribers, events)
+	close(events)
+	uc.subscribers--
+
+	if len(w.subscribers) == 0 {
+		w.cancel()
+
+		if uc.watches[key] == w {
+			delete(uc.watches, key)
+		}
+	}
+}
+
+func (uc *WatchByCityNameUseCase) poll(ctx context.Context, w *watch) {
+	ticker := time.NewTicker(uc.Settings.Interval)
+	defer ticker.Stop()
+
+	for {
+		uc.fetch(ctx, w)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (uc *WatchByCityNameUseCase) fetch(ctx context.Context, w *watch) {
+	ctx, span := uc.Tracer.Start(ctx, "poll-climate", trace.WithLinks(uc.links(w)...), trace.WithAttributes(
+		attribute.String("city", w.city),
+		attribute.String("region", w.region),
+	))
+	defer span.End()
+
+	climate, err := uc.Next.Execute(ctx, w.city, w.region)
+	if ctx.Err() != nil {
+		return
+	}
+
+	if err != nil {
+		span.SetStatus(codes.Error, "error polling climate")
+		span.RecordError(err)
+
+		uc.Logger.Warn().Msgf("[WatchByCityName] Error polling climate for city [%s]: %s", w.city, err)
+	}
+
+	span.SetAttributes(attribute.Bool("stream.published", uc.publish(w, WatchEvent{Climate: climate, Err: err})))
+}
+
+func (uc *WatchByCityNameUseCase) links(w *watch) []trace.Link {
+	uc.mu.Lock()
+	defer uc.mu.Unlock()
+
+	links := make([]trace.Link, 0, len(w.subscribers))
+	for _, spanContext := range w.subscribers {
+		if spanContext.IsValid() {
+			links = append(links, trace.Link{SpanContext: spanContext})
+		}
+	}
+
+	return links
+}
+
+// publish reports whether the event was new to the subscribers.
+func (uc *WatchByCityNameUseCase) publish(w *watch, event WatchEvent) bool {
+	uc.mu.Lock()
+	defer uc.mu.Unlock()
+
+	if last := w.last; last != nil {
+		if event.Err != nil && last.Err != nil {
+			return false
+		}
+
+		if event.Climate != nil && last.Climate != nil && sameReading(event.Climate.Current, last.Climate.Current) {
+			return false
+		}
+	}
+
+	w.last = &event
+
+	for events := range w.subscribers {
+		select {
+		case <-events:
+		default:
+		}
+
+		events <- event
+	}
+
+	return true
+}
+
+// sameReading tells observations apart by ObservedAt. Providers that do not report when they
+// observed leave it zero, so those readings are compared by value instead.
+func sameReading(a, b entities.ClimateData) bool {
+	if !a.ObservedAt.IsZero() || !b.ObservedAt.IsZero() {
+		return a.ObservedAt.Equal(b.ObservedAt)
+	}
+
+	return a == b
+}
@@ -0,0 +1,203 @@
+package climate
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace/noop"
+
+	"github.com/wellalencarweb/otel-lab-challenge/internal/entities"
+	"github.com/wellalencarweb/otel-lab-challenge/internal/pkg/customerrors"
+	"github.com/wellalencarweb/otel-lab-challenge/internal/pkg/mocks"
+)
+
+type WatchByCityNameUseCaseTestSuite struct {
+	suite.Suite
+	FindByCityNameUseCaseMock *mocks.FindByCityNameUseCaseMock
+	ObservedAt                time.Time
+}
+
+func TestWatchByCityNameUseCase(t *testing.T) {
+	suite.Run(t, new(WatchByCityNameUseCaseTestSuite))
+}
+
+func (s *WatchByCityNameUseCaseTestSuite) SetupTest() {
+	s.FindByCityNameUseCaseMock = new(mocks.FindByCityNameUseCaseMock)
+	s.ObservedAt = time.Unix(1760720400, 0)
+}
+
+func (s *WatchByCityNameUseCaseTestSuite) newUseCase(settings WatchSettings) *WatchByCityNameUseCase {
+	uc := NewWatchByCityNameUseCase(s.FindByCityNameUseCaseMock, settings, zerolog.Nop(), noop.NewTracerProvider().Tracer("watch-test"))
+	s.T().Cleanup(func() { uc.Close() })
+
+	return uc
+}
+
+func (s *WatchByCityNameUseCaseTestSuite) climate(observedAt time.Time, tempC float64) *entities.Climate {
+	return &entities.Climate{
+		Current: entities.ClimateData{
+			ObservedAt: observedAt,
+			TempC:      tempC,
+		},
+	}
+}
+
+func (s *WatchByCityNameUseCaseTestSuite) receive(events <-chan WatchEvent) WatchEvent {
+	select {
+	case event, ok := <-events:
+		s.Require().True(ok, "subscription closed")
+		return event
+	case <-time.After(time.Second):
+		s.FailNow("no event received")
+		return WatchEvent{}
+	}
+}
+
+func (s *WatchByCityNameUseCaseTestSuite) TestSharesOnePollBetweenSubscribers() {
+	s.FindByCityNameUseCaseMock.On("Execute", mock.Anything, "São Paulo", "SP").Return(s.climate(s.ObservedAt, 23), nil)
+
+	uc := s.newUseCase(WatchSettings{Interval: time.Hour})
+
+	first, err := uc.Subscribe(context.Background(), "São Paulo", "SP")
+	s.Require().NoError(err)
+	s.Equal(23.0, s.receive(first).Climate.Current.TempC)
+
+	second, err := uc.Subscribe(context.Background(), "Sao Paulo", "sp")
+	s.Require().NoError(err)
+	s.Equal(23.0, s.receive(second).Climate.Current.TempC)
+
+	s.FindByCityNameUseCaseMock.AssertNumberOfCalls(s.T(), "Execute", 1)
+}
+
+func (s *WatchByCityNameUseCaseTestSuite) TestLinksPollsToSubscribers() {
+	s.FindByCityNameUseCaseMock.On("Execute", mock.Anything, "Belém", "PA").Return(s.climate(s.ObservedAt, 31), nil)
+
+	recorder := tracetest.NewSpanRecorder()
+	tracer := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)).Tracer("watch-test")
+
+	uc := NewWatchByCityNameUseCase(s.FindByCityNameUseCaseMock, WatchSettings{Interval: time.Hour}, zerolog.Nop(), tracer)
+	s.T().Cleanup(func() { uc.Close() })
+
+	ctx, span := tracer.Start(context.Background(), "stream")
+	defer span.End()
+
+	events, err := uc.Subscribe(ctx, "Belém", "PA")
+	s.Require().NoError(err)
+	s.receive(events)
+
+	s.Require().Eventually(func() bool { return len(recorder.Ended()) > 0 }, time.Second, time.Millisecond)
+
+	poll := recorder.Ended()[0]
+	s.Equal("poll-climate", poll.Name())
+	s.False(poll.Parent().IsValid())
+	s.Require().Len(poll.Links(), 1)
+	s.Equal(span.SpanContext().SpanID(), poll.Links()[0].SpanContext.SpanID())
+}
+
+func (s *WatchByCityNameUseCaseTestSuite) TestPushesOnlyNewObservations() {
+	updatedAt := s.ObservedAt.Add(15 * time.Minute)
+
+	s.FindByCityNameUseCaseMock.On("Execute", mock.Anything, "Rio de Janeiro", "RJ").Return(s.climate(s.ObservedAt, 30), nil).Once()
+	s.FindByCityNameUseCaseMock.On("Execute", mock.Anything, "Rio de Janeiro", "RJ").Return(s.climate(s.ObservedAt, 31), nil).Once()
+	s.FindByCityNameUseCaseMock.On("Execute", mock.Anything, "Rio de Janeiro", "RJ").Return(s.climate(updatedAt, 32), nil)
+
+	uc := s.newUseCase(WatchSettings{Interval: 5 * time.Millisecond})
+
+	events, err := uc.Subscribe(context.Background(), "Rio de Janeiro", "RJ")
+	s.Require().NoError(err)
+
+	s.Equal(30.0, s.receive(events).Climate.Current.TempC)
+
+	event := s.receive(events)
+	s.Equal(32.0, event.Climate.Current.TempC)
+	s.Equal(updatedAt, event.Climate.Current.ObservedAt)
+}
+
+func (s *WatchByCityNameUseCaseTestSuite) TestComparesReadingsWithoutObservationTime() {
+	s.FindByCityNameUseCaseMock.On("Execute", mock.Anything, "Curitiba", "PR").Return(s.climate(time.Time{}, 18), nil).Twice()
+	s.FindByCityNameUseCaseMock.On("Execute", mock.Anything, "Curitiba", "PR").Return(s.climate(time.Time{}, 19), nil)
+
+	uc := s.newUseCase(WatchSettings{Interval: 5 * time.Millisecond})
+
+	events, err := uc.Subscribe(context.Background(), "Curitiba", "PR")
+	s.Require().NoError(err)
+
+	s.Equal(18.0, s.receive(events).Climate.Current.TempC)
+	s.Equal(19.0, s.receive(events).Climate.Current.TempC)
+}
+
+func (s *WatchByCityNameUseCaseTestSuite) TestPushesErrorsOnce() {
+	s.FindByCityNameUseCaseMock.On("Execute", mock.Anything, "Rio Branco", "AC").Return((*entities.Climate)(nil), errors.New("weatherapi is down")).Twice()
+	s.FindByCityNameUseCaseMock.On("Execute", mock.Anything, "Rio Branco", "AC").Return(s.climate(s.ObservedAt, 31), nil)
+
+	uc := s.newUseCase(WatchSettings{Interval: 5 * time.Millisecond})
+
+	events, err := uc.Subscribe(context.Background(), "Rio Branco", "AC")
+	s.Require().NoError(err)
+
+	s.EqualError(s.receive(events).Err, "weatherapi is down")
+	s.Equal(31.0, s.receive(events).Climate.Current.TempC)
+}
+
+func (s *WatchByCityNameUseCaseTestSuite) TestStopsPollingWithoutSubscribers() {
+	s.FindByCityNameUseCaseMock.On("Execute", mock.Anything, "Manaus", "AM").Return(s.climate(s.ObservedAt, 33), nil)
+
+	uc := s.newUseCase(WatchSettings{Interval: time.Hour})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	events, err := uc.Subscribe(ctx, "Manaus", "AM")
+	s.Require().NoError(err)
+	s.receive(events)
+
+	cancel()
+
+	s.Eventually(func() bool {
+		_, ok := <-events
+		return !ok
+	}, time.Second, time.Millisecond)
+
+	uc.mu.Lock()
+	defer uc.mu.Unlock()
+
+	s.Empty(uc.watches)
+	s.Zero(uc.subscribers)
+}
+
+func (s *WatchByCityNameUseCaseTestSuite) TestRefusesSubscribersOverTheLimit() {
+	s.FindByCityNameUseCaseMock.On("Execute", mock.Anything, mock.Anything, mock.Anything).Return(s.climate(s.ObservedAt, 20), nil)
+
+	uc := s.newUseCase(WatchSettings{Interval: time.Hour, MaxSubscribers: 1})
+
+	_, err := uc.Subscribe(context.Background(), "Curitiba", "PR")
+	s.Require().NoError(err)
+
+	events, err := uc.Subscribe(context.Background(), "Porto Alegre", "RS")
+	s.Nil(events)
+	s.IsType(&customerrors.ServiceUnavailableError{}, err)
+	s.ErrorIs(err.(*customerrors.ServiceUnavailableError).Err, ErrTooManySubscribers)
+}
+
+func (s *WatchByCityNameUseCaseTestSuite) TestCloseEndsSubscriptions() {
+	s.FindByCityNameUseCaseMock.On("Execute", mock.Anything, "Recife", "PE").Return(s.climate(s.ObservedAt, 29), nil)
+
+	uc := s.newUseCase(WatchSettings{Interval: time.Hour})
+
+	events, err := uc.Subscribe(context.Background(), "Recife", "PE")
+	s.Require().NoError(err)
+	s.receive(events)
+
+	s.Nil(uc.Close())
+
+	_, ok := <-events
+	s.False(ok)
+
+	_, err = uc.Subscribe(context.Background(), "Recife", "PE")
+	s.IsType(&customerrors.ServiceUnavailableError{}, err)
+}
@@ -0,0 +1,271 @@
+package climate
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/wellalencarweb/otel-lab-challenge/internal/entities"
+	"github.com/wellalencarweb/otel-lab-challenge/internal/pkg/customerrors"
+	"github.com/wellalencarweb/otel-lab-challenge/internal/pkg/httpclient"
+)
+
+type weatherApiResponse struct {
+	Location struct {
+		Name    string  `json:"name"`
+		Region  string  `json:"region"`
+		Country string  `json:"country"`
+		Lat     float64 `json:"lat"`
+		Lon     float64 `json:"lon"`
+		TzID    string  `json:"tz_id"`
+	} `json:"location"`
+	Current struct {
+		LastUpdatedEpoch int64   `json:"last_updated_epoch"`
+		TempC            float64 `json:"temp_c"`
+		IsDay            int     `json:"is_day"`
+		Condition        struct {
+			Text string `json:"text"`
+		} `json:"condition"`
+		WindKph    float64 `json:"wind_kph"`
+		WindDegree int     `json:"wind_degree"`
+		PressureMb float64 `json:"pressure_mb"`
+		PrecipMm   float64 `json:"precip_mm"`
+		Humidity   int     `json:"humidity"`
+		FeelslikeC float64 `json:"feelslike_c"`
+		Uv         float64 `json:"uv"`
+	} `json:"current"`
+}
+
+type weatherApiForecastResponse struct {
+	weatherApiResponse
+	Forecast struct {
+		ForecastDay []struct {
+			Date string `json:"date"`
+			Day  struct {
+				MaxTempC  float64 `json:"maxtemp_c"`
+				MinTempC  float64 `json:"mintemp_c"`
+				AvgTempC  float64 `json:"avgtemp_c"`
+				Condition struct {
+					Text string `json:"text"`
+				} `json:"condition"`
+			} `json:"day"`
+			Hour []struct {
+				TimeEpoch int64   `json:"time_epoch"`
+				TempC     float64 `json:"temp_c"`
+				Condition struct {
+					Text string `json:"text"`
+				} `json:"condition"`
+			} `json:"hour"`
+		} `json:"forecastday"`
+	} `json:"forecast"`
+}
+
+// weatherApiNoMatchingLocation is the error code WeatherAPI answers when no location matches
+// the query; other 400 codes are bad requests, such as a missing key or parameter.
+const weatherApiNoMatchingLocation = 1006
+
+const (
+	// weatherApiMaxForecastDays is the most forecast days the free plan answers.
+	weatherApiMaxForecastDays = 3
+	// weatherApiLatestHour is the latest hour of the day a location can be at.
+	weatherApiLatestHour = 23
+)
+
+type weatherApiErrorResponse struct {
+	Error struct {
+		Code    int    `json:"code"`
+		Message string `json:"message"`
+	} `json:"error"`
+}
+
+type WeatherApiProvider struct {
+	HttpClient httpclient.HttpClientInterface
+	APIKey     string
+
+	now func() time.Time
+}
+
+func NewWeatherApiProvider(httpClient httpclient.HttpClientInterface, apiKey string) *WeatherApiProvider {
+	return &WeatherApiProvider{
+		HttpClient: httpClient,
+		APIKey:     apiKey,
+		now:        time.Now,
+	}
+}
+
+func (p *WeatherApiProvider) Name() string {
+	return "weatherapi"
+}
+
+func (p *WeatherApiProvider) CurrentWeather(ctx context.Context, query entities.ClimateQuery) (*entities.Climate, error) {
+	var response weatherApiResponse
+
+	if err := p.HttpClient.Get(
+		ctx,
+		"/v1/current.json",
+		&response,
+		httpclient.WithQuery("key", p.APIKey),
+		httpclient.WithQuery("q", weatherApiQuery(query)),
+		httpclient.WithQuery("aqi", "no"),
+	); err != nil {
+		return nil, p.asCustomError(err, query)
+	}
+
+	climate := &entities.Climate{
+		Provider: p.Name(),
+		Location: response.location(),
+		Current: entities.ClimateData{
+			TempC:      response.Current.TempC,
+			FeelsLikeC: response.Current.FeelslikeC,
+			Humidity:   response.Current.Humidity,
+			WindKph:    response.Current.WindKph,
+			WindDegree: response.Current.WindDegree,
+			PressureMb: response.Current.PressureMb,
+			PrecipMm:   response.Current.PrecipMm,
+			Uv:         response.Current.Uv,
+			Condition:  response.Current.Condition.Text,
+			IsDay:      response.Current.IsDay == 1,
+		},
+	}
+
+	if response.Current.LastUpdatedEpoch > 0 {
+		climate.Current.ObservedAt = time.Unix(response.Current.LastUpdatedEpoch, 0)
+	}
+
+	return climate, nil
+}
+
+func (p *WeatherApiProvider) Forecast(ctx context.Context, query entities.ClimateQuery, days int) (*entities.Forecast, error) {
+	response, err := p.fetchForecast(ctx, query, days)
+	if err != nil {
+		return nil, err
+	}
+
+	forecast := &entities.Forecast{
+		Provider: p.Name(),
+		Location: response.location(),
+		Days:     make([]entities.ForecastDay, 0, len(response.Forecast.ForecastDay)),
+	}
+
+	for _, day := range response.Forecast.ForecastDay {
+		forecast.Days = append(forecast.Days, entities.ForecastDay{
+			Date:      day.Date,
+			MinTempC:  day.Day.MinTempC,
+			MaxTempC:  day.Day.MaxTempC,
+			AvgTempC:  day.Day.AvgTempC,
+			Condition: day.Day.Condition.Text,
+		})
+	}
+
+	return forecast, nil
+}
+
+// HourlyForecast asks for enough whole days, as WeatherAPI has no hourly range, and keeps the
+// hours from the current one on. The location's local hour is only known after the call, so the
+// days are counted from the latest hour it can be, capped at what the plan serves.
+func (p *WeatherApiProvider) HourlyForecast(ctx context.Context, query entities.ClimateQuery, hours int) (*entities.Forecast, error) {
+	days := min((hours+weatherApiLatestHour+23)/24, weatherApiMaxForecastDays)
+
+	response, err := p.fetchForecast(ctx, query, days)
+	if err != nil {
+		return nil, err
+	}
+
+	forecast := &entities.Forecast{
+		Provider: p.Name(),
+		Location: response.location(),
+		Hours:    make([]entities.ForecastHour, 0, hours),
+	}
+
+	currentHour := p.now().Truncate(time.Hour)
+
+	for _, day := range response.Forecast.ForecastDay {
+		for _, hour := range day.Hour {
+			at := time.Unix(hour.TimeEpoch, 0).UTC()
+			if at.Before(currentHour) || len(forecast.Hours) == hours {
+				continue
+			}
+
+			forecast.Hours = append(forecast.Hours, entities.ForecastHour{
+				Time:      at,
+				TempC:     hour.TempC,
+				Condition: hour.Condition.Text,
+			})
+		}
+	}
+
+	return forecast, nil
+}
+
+func (p *WeatherApiProvider) fetchForecast(ctx context.Context, query entities.ClimateQuery, days int) (*weatherApiForecastResponse, error) {
+	var response weatherApiForecastResponse
+
+	if err := p.HttpClient.Get(
+		ctx,
+		"/v1/forecast.json",
+		&response,
+		httpclient.WithQuery("key", p.APIKey),
+		httpclient.WithQuery("q", weatherApiQuery(query)),
+		httpclient.WithQuery("days", strconv.Itoa(days)),
+		httpclient.WithQuery("aqi", "no"),
+		httpclient.WithQuery("alerts", "no"),
+	); err != nil {
+		return nil, p.asCustomError(err, query)
+	}
+
+	return &response, nil
+}
+
+func (p *WeatherApiProvider) asCustomError(err *httpclient.HttpClientError, query entities.ClimateQuery) error {
+	tags := map[string]interface{}{
+		"city":     query.City,
+		"region":   query.Region,
+		"provider": p.Name(),
+	}
+
+	if err.StatusCode == http.StatusBadRequest && weatherApiErrorCode(err.Body) == weatherApiNoMatchingLocation {
+		return &customerrors.NotFoundError{
+			Err:     err,
+			Message: "can not find climate for city",
+			Tags:    tags,
+		}
+	}
+
+	return err.AsCustomError("can not find climate for city", "Unknown error getting climate", tags)
+}
+
+func weatherApiErrorCode(body string) int {
+	var response weatherApiErrorResponse
+	if err := json.Unmarshal([]byte(body), &response); err != nil {
+		return 0
+	}
+
+	return response.Error.Code
+}
+
+func (r weatherApiResponse) location() entities.ClimateLocation {
+	return entities.ClimateLocation{
+		Name:      r.Location.Name,
+		Region:    r.Location.Region,
+		Country:   r.Location.Country,
+		Latitude:  r.Location.Lat,
+		Longitude: r.Location.Lon,
+		TimeZone:  r.Location.TzID,
+	}
+}
+
+// weatherApiQuery asks for "lat,lon" when the coordinates are resolved, and for "city, region"
+// otherwise, so WeatherAPI does not pick a namesake city in another state.
+func weatherApiQuery(query entities.ClimateQuery) string {
+	if query.Coordinates != nil {
+		return formatCoordinate(query.Coordinates.Latitude) + "," + formatCoordinate(query.Coordinates.Longitude)
+	}
+
+	if query.Region == "" {
+		return query.City
+	}
+
+	return query.City + ", " + query.Region
+}
@@ -0,0 +1,237 @@
+package climate
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/wellalencarweb/otel-lab-challenge/internal/entities"
+	"github.com/wellalencarweb/otel-lab-challenge/internal/pkg/customerrors"
+	"github.com/wellalencarweb/otel-lab-challenge/internal/pkg/httpclient"
+	"github.com/wellalencarweb/otel-lab-challenge/internal/pkg/mocks"
+	"github.com/wellalencarweb/otel-lab-challenge/internal/pkg/mocks/cassettetest"
+)
+
+const API_KEY = "any-api-key"
+
+type WeatherApiProviderTestSuite struct {
+	suite.Suite
+	HttpClientMock     *mocks.HttpClientMock
+	WeatherApiProvider *WeatherApiProvider
+}
+
+func TestWeatherApiProvider(t *testing.T) {
+	suite.Run(t, new(WeatherApiProviderTestSuite))
+}
+
+func (s *WeatherApiProviderTestSuite) SetupTest() {
+	httpClientMock := new(mocks.HttpClientMock)
+
+	s.HttpClientMock = httpClientMock
+	s.WeatherApiProvider = NewWeatherApiProvider(httpClientMock, API_KEY)
+}
+
+func (s *WeatherApiProviderTestSuite) clearMocks() {
+	s.HttpClientMock.ExpectedCalls = nil
+}
+
+func (s *WeatherApiProviderTestSuite) TestCurrentWeather() {
+	s.Run("should return weather", func() {
+		defer s.clearMocks()
+
+		ctx := context.Background()
+		city := "Rio de Janeiro"
+		options := httpclient.NewRequestOptions(
+			httpclient.WithQuery("key", API_KEY),
+			httpclient.WithQuery("q", "Rio de Janeiro, RJ"),
+			httpclient.WithQuery("aqi", "no"),
+		)
+
+		s.HttpClientMock.On("Get", ctx, "/v1/current.json", &weatherApiResponse{}, options).Return(nil)
+
+		result, err := s.WeatherApiProvider.CurrentWeather(ctx, entities.ClimateQuery{City: city, Region: "RJ"})
+
+		s.Nil(err)
+		s.NotNil(result)
+	})
+
+	s.Run("should query by coordinates when they are resolved", func() {
+		defer s.clearMocks()
+
+		ctx := context.Background()
+		options := httpclient.NewRequestOptions(
+			httpclient.WithQuery("key", API_KEY),
+			httpclient.WithQuery("q", "-22.9068,-43.1729"),
+			httpclient.WithQuery("aqi", "no"),
+		)
+
+		s.HttpClientMock.On("Get", ctx, "/v1/current.json", &weatherApiResponse{}, options).Return(nil)
+
+		result, err := s.WeatherApiProvider.CurrentWeather(ctx, entities.ClimateQuery{
+			City:        "Rio de Janeiro",
+			Region:      "RJ",
+			Coordinates: &entities.Coordinates{Latitude: -22.90685, Longitude: -43.1729},
+		})
+
+		s.Nil(err)
+		s.NotNil(result)
+	})
+
+	s.Run("should return error when http client returns error", func() {
+		defer s.clearMocks()
+
+		ctx := context.Background()
+		city := "Rio de Janeiro"
+		options := httpclient.NewRequestOptions(
+			httpclient.WithQuery("key", API_KEY),
+			httpclient.WithQuery("q", "Rio de Janeiro, RJ"),
+			httpclient.WithQuery("aqi", "no"),
+		)
+
+		s.HttpClientMock.On("Get", ctx, "/v1/current.json", &weatherApiResponse{}, options).Return(&httpclient.HttpClientError{
+			Err: fmt.Errorf("any-error"),
+		})
+
+		result, err := s.WeatherApiProvider.CurrentWeather(ctx, entities.ClimateQuery{City: city, Region: "RJ"})
+
+		s.Error(err)
+		s.Nil(result)
+	})
+	s.Run("should return not found when weather api can not match the city", func() {
+		defer s.clearMocks()
+
+		ctx := context.Background()
+		city := "Cidade Inexistente"
+		options := httpclient.NewRequestOptions(
+			httpclient.WithQuery("key", API_KEY),
+			httpclient.WithQuery("q", "Cidade Inexistente, RJ"),
+			httpclient.WithQuery("aqi", "no"),
+		)
+
+		s.HttpClientMock.On("Get", ctx, "/v1/current.json", &weatherApiResponse{}, options).Return(&httpclient.HttpClientError{
+			StatusCode: http.StatusBadRequest,
+			Body:       `{"error":{"code":1006,"message":"No matching location found."}}`,
+			Err:        fmt.Errorf("bad request"),
+		})
+
+		result, err := s.WeatherApiProvider.CurrentWeather(ctx, entities.ClimateQuery{City: city, Region: "RJ"})
+
+		s.Nil(result)
+		s.IsType(&customerrors.NotFoundError{}, err)
+	})
+
+	s.Run("should return unknown error for other weather api bad requests", func() {
+		defer s.clearMocks()
+
+		ctx := context.Background()
+		options := httpclient.NewRequestOptions(
+			httpclient.WithQuery("key", API_KEY),
+			httpclient.WithQuery("q", "Rio de Janeiro, RJ"),
+			httpclient.WithQuery("aqi", "no"),
+		)
+
+		s.HttpClientMock.On("Get", ctx, "/v1/current.json", &weatherApiResponse{}, options).Return(&httpclient.HttpClientError{
+			StatusCode: http.StatusBadRequest,
+			Body:       `{"error":{"code":1003,"message":"Parameter q is missing."}}`,
+			Err:        fmt.Errorf("bad request"),
+		})
+
+		result, err := s.WeatherApiProvider.CurrentWeather(ctx, entities.ClimateQuery{City: "Rio de Janeiro", Region: "RJ"})
+
+		s.Nil(result)
+		s.IsType(&customerrors.UnknownError{}, err)
+	})
+}
+
+func (s *WeatherApiProviderTestSuite) TestHourlyForecastDays() {
+	for _, tt := range []struct {
+		hours int
+		days  string
+	}{
+		{hours: 1, days: "1"},
+		{hours: 24, days: "2"},
+		{hours: 48, days: "3"},
+	} {
+		s.Run(fmt.Sprintf("should ask for %s days for %d hours", tt.days, tt.hours), func() {
+			defer s.clearMocks()
+
+			ctx := context.Background()
+			options := httpclient.NewRequestOptions(
+				httpclient.WithQuery("key", API_KEY),
+				httpclient.WithQuery("q", "Rio de Janeiro, RJ"),
+				httpclient.WithQuery("days", tt.days),
+				httpclient.WithQuery("aqi", "no"),
+				httpclient.WithQuery("alerts", "no"),
+			)
+
+			s.HttpClientMock.On("Get", ctx, "/v1/forecast.json", &weatherApiForecastResponse{}, options).Return(nil).Once()
+
+			_, err := s.WeatherApiProvider.HourlyForecast(ctx, entities.ClimateQuery{City: "Rio de Janeiro", Region: "RJ"}, tt.hours)
+
+			s.Require().NoError(err)
+			s.HttpClientMock.AssertExpectations(s.T())
+		})
+	}
+}
+
+func (s *WeatherApiProviderTestSuite) TestCurrentWeatherWithCassette() {
+	apiKey := os.Getenv("WEATHER_API_KEY")
+	if apiKey == "" {
+		apiKey = API_KEY
+	}
+
+	httpClient := cassettetest.NewHttpClient(s.T(), "weatherapi", "https://api.weatherapi.com", "weatherapi.json")
+	provider := NewWeatherApiProvider(httpClient, apiKey)
+
+	s.Run("should decode weather", func() {
+		result, err := provider.CurrentWeather(context.Background(), entities.ClimateQuery{City: "Rio de Janeiro", Region: "RJ"})
+
+		s.Require().NoError(err)
+		s.Equal("weatherapi", result.Provider)
+		s.Equal("America/Sao_Paulo", result.Location.TimeZone)
+		s.Equal(27.2, result.Current.TempC)
+		s.Equal("Partly cloudy", result.Current.Condition)
+		s.Equal(65, result.Current.Humidity)
+		s.Equal(int64(1760720400), result.Current.ObservedAt.Unix())
+	})
+
+	s.Run("should decode the daily forecast", func() {
+		result, err := provider.Forecast(context.Background(), entities.ClimateQuery{City: "Rio de Janeiro", Region: "RJ"}, 2)
+
+		s.Require().NoError(err)
+		s.Equal("weatherapi", result.Provider)
+		s.Equal("America/Sao_Paulo", result.Location.TimeZone)
+		s.Equal([]entities.ForecastDay{
+			{Date: "2025-10-17", MinTempC: 21.3, MaxTempC: 29.8, AvgTempC: 25.1, Condition: "Patchy rain nearby"},
+			{Date: "2025-10-18", MinTempC: 20.9, MaxTempC: 27.4, AvgTempC: 23.6, Condition: "Partly cloudy"},
+		}, result.Days)
+	})
+
+	s.Run("should decode the hourly forecast from the current hour on", func() {
+		provider.now = func() time.Time { return time.Unix(1760722200, 0) }
+		defer func() { provider.now = time.Now }()
+
+		result, err := provider.HourlyForecast(context.Background(), entities.ClimateQuery{City: "Rio de Janeiro", Region: "RJ"}, 3)
+
+		s.Require().NoError(err)
+		s.Equal("weatherapi", result.Provider)
+		s.Equal("America/Sao_Paulo", result.Location.TimeZone)
+		s.Equal([]entities.ForecastHour{
+			{Time: time.Unix(1760720400, 0).UTC(), TempC: 28.4, Condition: "Partly cloudy"},
+			{Time: time.Unix(1760724000, 0).UTC(), TempC: 27.8, Condition: "Partly cloudy"},
+			{Time: time.Unix(1760727600, 0).UTC(), TempC: 27.2, Condition: "Partly cloudy"},
+		}, result.Hours)
+	})
+
+	s.Run("should return not found when weather api can not match the city", func() {
+		result, err := provider.CurrentWeather(context.Background(), entities.ClimateQuery{City: "Atlantis"})
+
+		s.Nil(result)
+		s.IsType(&customerrors.NotFoundError{}, err)
+	})
+}
@@ -0,0 +1,54 @@
+package input
+
+import (
+	"context"
+
+	"github.com/rs/zerolog"
+
+	"github.com/wellalencarweb/otel-lab-challenge/internal/entities/dto"
+	"github.com/wellalencarweb/otel-lab-challenge/internal/pkg/httpclient"
+)
+
+type BatchUseCaseInterface interface {
+	Execute(ctx context.Context, input dto.BatchInput) (*dto.BatchOutput, error)
+}
+
+// BatchUseCase forwards a batch of zipcodes to the orchestrator, checking its size first so
+// oversized batches are rejected as validation errors rather than upstream failures.
+type BatchUseCase struct {
+	HttpClient httpclient.HttpClientInterface
+	MaxSize    int
+	Logger     zerolog.Logger
+}
+
+func NewBatchUseCase(
+	httpClient httpclient.HttpClientInterface,
+	maxSize int,
+	logger zerolog.Logger,
+) *BatchUseCase {
+	return &BatchUseCase{
+		HttpClient: httpClient,
+		MaxSize:    maxSize,
+		Logger:     logger,
+	}
+}
+
+func (uc *BatchUseCase) Execute(ctx context.Context, input dto.BatchInput) (*dto.BatchOutput, error) {
+	if err := input.Validate(uc.MaxSize); err != nil {
+		return nil, err
+	}
+
+	uc.Logger.Info().Msgf("[Batch] Calling Orchestrator API with [%d] zipcodes", len(input.Zipcodes))
+
+	var response dto.BatchOutput
+
+	if err := uc.HttpClient.Post(ctx, "/batch", input, &response); err != nil {
+		return nil, err.AsCustomError("can not find zipcodes", "Unknown error resolving batch", map[string]interface{}{
+			"zipCodes": len(input.Zipcodes),
+		})
+	}
+
+	uc.Logger.Debug().Msgf("[Batch] Resolved [%d] and failed [%d] zipcodes", response.Resolved, response.Failed)
+
+	return &response, nil
+}
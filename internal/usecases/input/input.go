@@ -2,13 +2,11 @@ package input
 
 import (
 	"context"
-	"fmt"
-	"net/http"
+	"strings"
 
 	"github.com/rs/zerolog"
 
 	"github.com/wellalencarweb/otel-lab-challenge/internal/entities/dto"
-	"github.com/wellalencarweb/otel-lab-challenge/internal/pkg/customerrors"
 	"github.com/wellalencarweb/otel-lab-challenge/internal/pkg/httpclient"
 )
 
@@ -40,24 +38,15 @@ func (uc *InputUseCase) Execute(ctx context.Context, input dto.InputUCInput) (*d
 
 	var response dto.GetTemperaturesByZipCodeOutput
 
-	if err := uc.HttpClient.Get(ctx, fmt.Sprintf("/?zipcode=%s", input.Zipcode), &response); err != nil {
-		if *err.StatusCode == http.StatusNotFound {
-			return nil, &customerrors.NotFoundError{
-				Err:     err.Error,
-				Message: "can not find zipcode",
-				Tags: map[string]interface{}{
-					"zipCode": input.Zipcode,
-				},
-			}
-		}
+	options := []httpclient.RequestOption{httpclient.WithQuery("zipcode", input.Zipcode)}
+	if len(input.Fields) > 0 {
+		options = append(options, httpclient.WithQuery("fields", strings.Join(input.Fields, ",")))
+	}
 
-		return nil, &customerrors.UnknownError{
-			Err:     err.Error,
-			Message: "Unknown error getting location",
-			Tags: map[string]interface{}{
-				"zipCode": input.Zipcode,
-			},
-		}
+	if err := uc.HttpClient.Get(ctx, "/", &response, options...); err != nil {
+		return nil, err.AsCustomError("can not find zipcode", "Unknown error getting location", map[string]interface{}{
+			"zipCode": input.Zipcode,
+		})
 	}
 
 	uc.Logger.Debug().Msgf("[Input] Got data: %+v", response)
@@ -0,0 +1,51 @@
+package location
+
+import (
+	"context"
+	"strings"
+
+	"github.com/wellalencarweb/otel-lab-challenge/internal/entities"
+	"github.com/wellalencarweb/otel-lab-challenge/internal/pkg/httpclient"
+)
+
+type brasilApiAddress struct {
+	Zipcode      string `json:"cep"`
+	State        string `json:"state"`
+	City         string `json:"city"`
+	Neighborhood string `json:"neighborhood"`
+	Street       string `json:"street"`
+}
+
+type BrasilApiProvider struct {
+	HttpClient httpclient.HttpClientInterface
+}
+
+func NewBrasilApiProvider(httpClient httpclient.HttpClientInterface) *BrasilApiProvider {
+	return &BrasilApiProvider{
+		HttpClient: httpClient,
+	}
+}
+
+func (p *BrasilApiProvider) Name() string {
+	return "brasilapi"
+}
+
+func (p *BrasilApiProvider) FindByZipCode(ctx context.Context, zipCode string) (*entities.Location, error) {
+	var address brasilApiAddress
+
+	if err := p.HttpClient.Get(ctx, "/cep/v1/{zipcode}", &address, httpclient.WithPathParam("zipcode", strings.ReplaceAll(zipCode, "-", ""))); err != nil {
+		return nil, err.AsCustomError("can not find zipcode", "Unknown error getting location", map[string]interface{}{
+			"zipCode":  zipCode,
+			"provider": p.Name(),
+		})
+	}
+
+	return &entities.Location{
+		Zipcode:      address.Zipcode,
+		AddressLine1: address.Street,
+		Neighborhood: address.Neighborhood,
+		City:         address.City,
+		State:        address.State,
+		Provider:     p.Name(),
+	}, nil
+}
@@ -0,0 +1,133 @@
+package location
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/rs/zerolog"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/wellalencarweb/otel-lab-challenge/internal/entities"
+	"github.com/wellalencarweb/otel-lab-challenge/internal/pkg/cache"
+	"github.com/wellalencarweb/otel-lab-challenge/internal/pkg/customerrors"
+)
+
+const (
+	cacheHitKey    = attribute.Key("cache.hit")
+	cacheResultKey = attribute.Key("cache.result")
+)
+
+type CacheSettings struct {
+	TTL         time.Duration
+	NegativeTTL time.Duration
+}
+
+// CachedLocation is what the cache backend stores for a zipcode, either its location or the
+// fact that it does not exist.
+type CachedLocation struct {
+	Location *entities.Location `json:"location,omitempty"`
+	NotFound bool               `json:"not_found,omitempty"`
+}
+
+// CachedFindByZipCodeUseCase decorates a FindByZipCodeUseCaseInterface with a TTL cache.
+// Unknown zipcodes are cached too, for NegativeTTL, so repeated lookups do not reach ViaCEP;
+// a zero NegativeTTL turns that off.
+type CachedFindByZipCodeUseCase struct {
+	Next     FindByZipCodeUseCaseInterface
+	Cache    cache.Backend[CachedLocation]
+	Settings CacheSettings
+	Logger   zerolog.Logger
+}
+
+func NewCachedFindByZipCodeUseCase(
+	next FindByZipCodeUseCaseInterface,
+	backend cache.Backend[CachedLocation],
+	settings CacheSettings,
+	logger zerolog.Logger,
+) *CachedFindByZipCodeUseCase {
+	return &CachedFindByZipCodeUseCase{
+		Next:     next,
+		Cache:    backend,
+		Settings: settings,
+		Logger:   logger,
+	}
+}
+
+func (uc *CachedFindByZipCodeUseCase) Execute(ctx context.Context, zipCode string) (*entities.Location, error) {
+	span := trace.SpanFromContext(ctx)
+	key := CacheKey(zipCode)
+
+	if cached, ok := uc.Cache.Get(key); ok {
+		result := "hit"
+		if cached.isNegative() {
+			result = "negative_hit"
+		}
+
+		span.SetAttributes(cacheHitKey.Bool(true), cacheResultKey.String(result))
+		uc.Logger.Debug().Msgf("[CachedFindByZipCode] Cache %s for zipcode [%s]", result, zipCode)
+
+		return cached.result(zipCode)
+	}
+
+	span.SetAttributes(cacheHitKey.Bool(false), cacheResultKey.String("miss"))
+
+	location, err := uc.Next.Execute(ctx, zipCode)
+
+	var notFoundErr *customerrors.NotFoundError
+	switch {
+	case errors.As(err, &notFoundErr):
+		uc.store(key, CachedLocation{NotFound: true})
+	case err == nil:
+		cached := CachedLocation{Location: location}
+		uc.store(key, cached)
+
+		return cached.result(zipCode)
+	}
+
+	return location, err
+}
+
+// CacheKey is the key a zipcode is cached under, so "22021-001" and "22021001" share an entry.
+func CacheKey(zipCode string) string {
+	return strings.ReplaceAll(zipCode, "-", "")
+}
+
+// store caches the entry for its TTL; entries whose TTL is not positive would already be
+// expired, so they are not stored rather than take the place of live ones.
+func (uc *CachedFindByZipCodeUseCase) store(key string, cached CachedLocation) {
+	ttl := uc.Settings.TTL
+	if cached.isNegative() {
+		ttl = uc.Settings.NegativeTTL
+	}
+
+	if ttl <= 0 {
+		return
+	}
+
+	uc.Cache.Set(key, cached, ttl)
+}
+
+// isNegative reports whether ViaCEP does not know the zipcode, either through a 404 or through
+// its 200 answer with an empty location.
+func (c CachedLocation) isNegative() bool {
+	return c.NotFound || c.Location == nil || c.Location.City == ""
+}
+
+func (c CachedLocation) result(zipCode string) (*entities.Location, error) {
+	if c.NotFound || c.Location == nil {
+		return nil, &customerrors.NotFoundError{
+			Err:     errors.New("zipcode not found"),
+			Message: "can not find zipcode",
+			Tags: map[string]interface{}{
+				"zipCode": zipCode,
+			},
+		}
+	}
+
+	location := *c.Location
+
+	return &location, nil
+}
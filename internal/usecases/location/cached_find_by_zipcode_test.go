@@ -0,0 +1,174 @@
+package location
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"go.opentelemetry.io/otel/attribute"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+
+	"github.com/wellalencarweb/otel-lab-challenge/internal/entities"
+	"github.com/wellalencarweb/otel-lab-challenge/internal/pkg/cache"
+	"github.com/wellalencarweb/otel-lab-challenge/internal/pkg/customerrors"
+	"github.com/wellalencarweb/otel-lab-challenge/internal/pkg/mocks"
+)
+
+type CachedFindByZipCodeUseCaseTestSuite struct {
+	suite.Suite
+	FindByZipCodeUseCaseMock   *mocks.FindByZipCodeUseCaseMock
+	CachedFindByZipCodeUseCase *CachedFindByZipCodeUseCase
+	SpanRecorder               *tracetest.SpanRecorder
+}
+
+func TestCachedFindByZipCodeUseCase(t *testing.T) {
+	suite.Run(t, new(CachedFindByZipCodeUseCaseTestSuite))
+}
+
+func (s *CachedFindByZipCodeUseCaseTestSuite) SetupTest() {
+	s.FindByZipCodeUseCaseMock = new(mocks.FindByZipCodeUseCaseMock)
+	s.SpanRecorder = tracetest.NewSpanRecorder()
+	s.CachedFindByZipCodeUseCase = NewCachedFindByZipCodeUseCase(s.FindByZipCodeUseCaseMock, cache.NewLRU[CachedLocation](10), CacheSettings{
+		TTL:         time.Hour,
+		NegativeTTL: time.Minute,
+	}, zerolog.Nop())
+}
+
+func (s *CachedFindByZipCodeUseCaseTestSuite) execute(zipCode string) (*entities.Location, map[attribute.Key]attribute.Value, error) {
+	tracer := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(s.SpanRecorder)).Tracer("cache-test")
+
+	ctx, span := tracer.Start(context.Background(), "find-location-by-zipcode")
+	location, err := s.CachedFindByZipCodeUseCase.Execute(ctx, zipCode)
+	span.End()
+
+	spans := s.SpanRecorder.Ended()
+	attrs := map[attribute.Key]attribute.Value{}
+	for _, attr := range spans[len(spans)-1].Attributes() {
+		attrs[attr.Key] = attr.Value
+	}
+
+	return location, attrs, err
+}
+
+func (s *CachedFindByZipCodeUseCaseTestSuite) TestCachedFindByZipCodeUseCase() {
+	s.Run("should call the upstream once and serve later lookups from cache", func() {
+		s.FindByZipCodeUseCaseMock.On("Execute", mock.Anything, "22021-001").Return(&entities.Location{City: "Rio de Janeiro"}, nil).Once()
+
+		location, attrs, err := s.execute("22021-001")
+		s.Nil(err)
+		s.Equal("Rio de Janeiro", location.City)
+		s.False(attrs[cacheHitKey].AsBool())
+		s.Equal("miss", attrs[cacheResultKey].AsString())
+
+		location.City = "changed by caller"
+
+		location, attrs, err = s.execute("22021001")
+		s.Nil(err)
+		s.Equal("Rio de Janeiro", location.City)
+		s.True(attrs[cacheHitKey].AsBool())
+		s.Equal("hit", attrs[cacheResultKey].AsString())
+
+		s.FindByZipCodeUseCaseMock.AssertExpectations(s.T())
+	})
+
+	s.Run("should cache unknown zipcodes", func() {
+		notFoundErr := &customerrors.NotFoundError{Message: "can not find zipcode"}
+		s.FindByZipCodeUseCaseMock.On("Execute", mock.Anything, "99999-999").Return((*entities.Location)(nil), notFoundErr).Once()
+		s.FindByZipCodeUseCaseMock.On("Execute", mock.Anything, "00000-000").Return(&entities.Location{}, nil).Once()
+
+		for i := 0; i < 2; i++ {
+			_, _, err := s.execute("99999-999")
+			s.IsType(notFoundErr, err)
+			s.Equal(notFoundErr.Message, err.(*customerrors.NotFoundError).Message)
+
+			location, _, err := s.execute("00000-000")
+			s.Nil(err)
+			s.Empty(location.City)
+		}
+
+		_, attrs, _ := s.execute("99999-999")
+		s.Equal("negative_hit", attrs[cacheResultKey].AsString())
+
+		s.FindByZipCodeUseCaseMock.AssertExpectations(s.T())
+	})
+
+	s.Run("should not cache upstream failures", func() {
+		unavailableErr := &customerrors.ServiceUnavailableError{Err: errors.New("viacep is unavailable")}
+		s.FindByZipCodeUseCaseMock.On("Execute", mock.Anything, "01001-000").Return((*entities.Location)(nil), unavailableErr).Twice()
+
+		_, _, err := s.execute("01001-000")
+		s.Equal(unavailableErr, err)
+
+		_, _, err = s.execute("01001-000")
+		s.Equal(unavailableErr, err)
+
+		s.FindByZipCodeUseCaseMock.AssertExpectations(s.T())
+	})
+}
+
+func (s *CachedFindByZipCodeUseCaseTestSuite) TestNegativeTTL() {
+	s.CachedFindByZipCodeUseCase.Settings.NegativeTTL = 20 * time.Millisecond
+	s.FindByZipCodeUseCaseMock.On("Execute", mock.Anything, "99999-999").Return(&entities.Location{}, nil).Twice()
+
+	s.execute("99999-999")
+	s.execute("99999-999")
+
+	time.Sleep(30 * time.Millisecond)
+	s.execute("99999-999")
+
+	s.FindByZipCodeUseCaseMock.AssertExpectations(s.T())
+}
+
+func (s *CachedFindByZipCodeUseCaseTestSuite) TestNegativeTTLDisabled() {
+	lru := cache.NewLRU[CachedLocation](10)
+	s.CachedFindByZipCodeUseCase = NewCachedFindByZipCodeUseCase(s.FindByZipCodeUseCaseMock, lru, CacheSettings{TTL: time.Hour}, zerolog.Nop())
+
+	s.FindByZipCodeUseCaseMock.On("Execute", mock.Anything, "99999-999").Return((*entities.Location)(nil), &customerrors.NotFoundError{}).Twice()
+	s.FindByZipCodeUseCaseMock.On("Execute", mock.Anything, "00000-000").Return(&entities.Location{}, nil).Once()
+
+	s.execute("99999-999")
+	s.execute("99999-999")
+	s.execute("00000-000")
+
+	s.Zero(lru.Len())
+	s.FindByZipCodeUseCaseMock.AssertExpectations(s.T())
+}
+
+func (s *CachedFindByZipCodeUseCaseTestSuite) TestFileBackend() {
+	path := filepath.Join(s.T().TempDir(), "locations.jsonl")
+	settings := CacheSettings{TTL: time.Hour, NegativeTTL: time.Minute}
+
+	store, err := cache.OpenFileStore[CachedLocation](path, 10, zerolog.Nop())
+	s.Require().NoError(err)
+
+	s.FindByZipCodeUseCaseMock.On("Execute", mock.Anything, "22021-001").Return(&entities.Location{City: "Rio de Janeiro"}, nil).Once()
+	s.FindByZipCodeUseCaseMock.On("Execute", mock.Anything, "99999-999").Return((*entities.Location)(nil), &customerrors.NotFoundError{}).Once()
+
+	s.CachedFindByZipCodeUseCase = NewCachedFindByZipCodeUseCase(s.FindByZipCodeUseCaseMock, store, settings, zerolog.Nop())
+	s.execute("22021-001")
+	s.execute("99999-999")
+	s.Require().NoError(store.Close())
+
+	store, err = cache.OpenFileStore[CachedLocation](path, 10, zerolog.Nop())
+	s.Require().NoError(err)
+	defer store.Close()
+
+	s.CachedFindByZipCodeUseCase = NewCachedFindByZipCodeUseCase(s.FindByZipCodeUseCaseMock, store, settings, zerolog.Nop())
+
+	location, attrs, err := s.execute("22021-001")
+	s.Nil(err)
+	s.Equal("Rio de Janeiro", location.City)
+	s.Equal("hit", attrs[cacheResultKey].AsString())
+
+	_, attrs, err = s.execute("99999-999")
+	s.IsType(&customerrors.NotFoundError{}, err)
+	s.Equal("negative_hit", attrs[cacheResultKey].AsString())
+
+	s.FindByZipCodeUseCaseMock.AssertExpectations(s.T())
+}
@@ -0,0 +1,37 @@
+package location
+
+import (
+	"context"
+	"strings"
+
+	"github.com/wellalencarweb/otel-lab-challenge/internal/entities"
+	"github.com/wellalencarweb/otel-lab-challenge/internal/pkg/singleflight"
+)
+
+// CoalescedFindByZipCodeUseCase shares a single upstream lookup between concurrent callers
+// asking for the same zipcode.
+type CoalescedFindByZipCodeUseCase struct {
+	Next FindByZipCodeUseCaseInterface
+
+	group singleflight.Group[*entities.Location]
+}
+
+func NewCoalescedFindByZipCodeUseCase(next FindByZipCodeUseCaseInterface) *CoalescedFindByZipCodeUseCase {
+	return &CoalescedFindByZipCodeUseCase{
+		Next: next,
+	}
+}
+
+func (uc *CoalescedFindByZipCodeUseCase) Execute(ctx context.Context, zipCode string) (*entities.Location, error) {
+	location, err := uc.group.Do(ctx, strings.ReplaceAll(zipCode, "-", ""), func(ctx context.Context) (*entities.Location, error) {
+		return uc.Next.Execute(ctx, zipCode)
+	})
+
+	if location == nil {
+		return nil, err
+	}
+
+	result := *location
+
+	return &result, err
+}
@@ -0,0 +1,74 @@
+package location
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+
+	"github.com/wellalencarweb/otel-lab-challenge/internal/entities"
+	"github.com/wellalencarweb/otel-lab-challenge/internal/pkg/mocks"
+)
+
+type CoalescedFindByZipCodeUseCaseTestSuite struct {
+	suite.Suite
+	FindByZipCodeUseCaseMock      *mocks.FindByZipCodeUseCaseMock
+	CoalescedFindByZipCodeUseCase *CoalescedFindByZipCodeUseCase
+}
+
+func TestCoalescedFindByZipCodeUseCase(t *testing.T) {
+	suite.Run(t, new(CoalescedFindByZipCodeUseCaseTestSuite))
+}
+
+func (s *CoalescedFindByZipCodeUseCaseTestSuite) SetupTest() {
+	s.FindByZipCodeUseCaseMock = new(mocks.FindByZipCodeUseCaseMock)
+	s.CoalescedFindByZipCodeUseCase = NewCoalescedFindByZipCodeUseCase(s.FindByZipCodeUseCaseMock)
+}
+
+func (s *CoalescedFindByZipCodeUseCaseTestSuite) TestCoalescedFindByZipCodeUseCase() {
+	started := make(chan struct{})
+	release := make(chan struct{})
+
+	s.FindByZipCodeUseCaseMock.On("Execute", mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) {
+			close(started)
+			<-release
+		}).
+		Return(&entities.Location{City: "Rio de Janeiro"}, nil).
+		Once()
+
+	zipCodes := []string{"22021-001", "22021001", "22021-001", "22021001"}
+	results := make([]*entities.Location, len(zipCodes))
+
+	var calling, done sync.WaitGroup
+	for i, zipCode := range zipCodes {
+		calling.Add(1)
+		done.Add(1)
+
+		go func(i int, zipCode string) {
+			defer done.Done()
+
+			calling.Done()
+			location, err := s.CoalescedFindByZipCodeUseCase.Execute(context.Background(), zipCode)
+
+			s.Nil(err)
+			results[i] = location
+		}(i, zipCode)
+	}
+
+	// the upstream lookup answers only once every caller has called Execute and it is running
+	calling.Wait()
+	<-started
+	close(release)
+
+	done.Wait()
+
+	for _, location := range results {
+		s.Equal("Rio de Janeiro", location.City)
+	}
+	s.NotSame(results[0], results[1])
+
+	s.FindByZipCodeUseCaseMock.AssertExpectations(s.T())
+}
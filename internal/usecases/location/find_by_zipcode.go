@@ -2,14 +2,11 @@ package location
 
 import (
 	"context"
-	"fmt"
-	"net/http"
 
 	"github.com/rs/zerolog"
+	"go.opentelemetry.io/otel/trace"
 
 	"github.com/wellalencarweb/otel-lab-challenge/internal/entities"
-	"github.com/wellalencarweb/otel-lab-challenge/internal/pkg/customerrors"
-	"github.com/wellalencarweb/otel-lab-challenge/internal/pkg/httpclient"
 )
 
 type FindByZipCodeUseCaseInterface interface {
@@ -17,46 +14,31 @@ type FindByZipCodeUseCaseInterface interface {
 }
 
 type FindByZipCodeUseCase struct {
-	HttpClient httpclient.HttpClientInterface
-	Logger     zerolog.Logger
+	Provider LocationProvider
+	Logger   zerolog.Logger
 }
 
 func NewFindByZipCodeUseCase(
-	httpClient httpclient.HttpClientInterface,
+	provider LocationProvider,
 	logger zerolog.Logger,
 ) *FindByZipCodeUseCase {
 	return &FindByZipCodeUseCase{
-		HttpClient: httpClient,
-		Logger:     logger,
+		Provider: provider,
+		Logger:   logger,
 	}
 }
 
 func (uc *FindByZipCodeUseCase) Execute(ctx context.Context, zipCode string) (*entities.Location, error) {
-	var location entities.Location
-
-	uc.Logger.Info().Msgf("[FindByZipCode] Calling API with zipcode [%s]", zipCode)
-
-	if err := uc.HttpClient.Get(ctx, fmt.Sprintf("/%s/json/", zipCode), &location); err != nil {
-		if *err.StatusCode == http.StatusNotFound {
-			return nil, &customerrors.NotFoundError{
-				Err:     err.Error,
-				Message: "can not find zipcode",
-				Tags: map[string]interface{}{
-					"zipCode": zipCode,
-				},
-			}
-		}
-
-		return nil, &customerrors.UnknownError{
-			Err:     err.Error,
-			Message: "Unknown error getting location",
-			Tags: map[string]interface{}{
-				"zipCode": zipCode,
-			},
-		}
+	uc.Logger.Info().Msgf("[FindByZipCode] Calling [%s] with zipcode [%s]", uc.Provider.Name(), zipCode)
+
+	location, err := uc.Provider.FindByZipCode(ctx, zipCode)
+	if err != nil {
+		return nil, err
 	}
 
-	uc.Logger.Debug().Msgf("[FindByZipCode] Got location [%+v]", location)
+	trace.SpanFromContext(ctx).SetAttributes(locationProviderKey.String(location.Provider))
+
+	uc.Logger.Debug().Msgf("[FindByZipCode] Got location [%+v]", *location)
 
-	return &location, nil
+	return location, nil
 }
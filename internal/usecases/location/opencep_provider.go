@@ -0,0 +1,55 @@
+package location
+
+import (
+	"context"
+	"strings"
+
+	"github.com/wellalencarweb/otel-lab-challenge/internal/entities"
+	"github.com/wellalencarweb/otel-lab-challenge/internal/pkg/httpclient"
+)
+
+type openCepAddress struct {
+	Zipcode      string `json:"cep"`
+	AddressLine1 string `json:"logradouro"`
+	AddressLine2 string `json:"complemento"`
+	Neighborhood string `json:"bairro"`
+	City         string `json:"localidade"`
+	State        string `json:"uf"`
+	IBGECode     string `json:"ibge"`
+}
+
+type OpenCepProvider struct {
+	HttpClient httpclient.HttpClientInterface
+}
+
+func NewOpenCepProvider(httpClient httpclient.HttpClientInterface) *OpenCepProvider {
+	return &OpenCepProvider{
+		HttpClient: httpClient,
+	}
+}
+
+func (p *OpenCepProvider) Name() string {
+	return "opencep"
+}
+
+func (p *OpenCepProvider) FindByZipCode(ctx context.Context, zipCode string) (*entities.Location, error) {
+	var address openCepAddress
+
+	if err := p.HttpClient.Get(ctx, "/v1/{zipcode}", &address, httpclient.WithPathParam("zipcode", strings.ReplaceAll(zipCode, "-", ""))); err != nil {
+		return nil, err.AsCustomError("can not find zipcode", "Unknown error getting location", map[string]interface{}{
+			"zipCode":  zipCode,
+			"provider": p.Name(),
+		})
+	}
+
+	return &entities.Location{
+		Zipcode:      address.Zipcode,
+		AddressLine1: address.AddressLine1,
+		AddressLine2: address.AddressLine2,
+		Neighborhood: address.Neighborhood,
+		City:         address.City,
+		State:        address.State,
+		IBGECode:     address.IBGECode,
+		Provider:     p.Name(),
+	}, nil
+}
@@ -0,0 +1,84 @@
+package location
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/rs/zerolog"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/wellalencarweb/otel-lab-challenge/internal/entities"
+	"github.com/wellalencarweb/otel-lab-challenge/internal/pkg/customerrors"
+)
+
+const locationProviderKey = attribute.Key("location.provider")
+
+// LocationProvider resolves a zipcode through one upstream, translating its answer into an
+// entities.Location tagged with the provider name.
+type LocationProvider interface {
+	Name() string
+	FindByZipCode(ctx context.Context, zipCode string) (*entities.Location, error)
+}
+
+type ChainedProvider struct {
+	Provider LocationProvider
+	Timeout  time.Duration
+}
+
+// LocationProviderChain tries its providers in order, each bounded by its own timeout, until
+// one answers. A not found answer is final: it is returned right away instead of asking the
+// next provider, so only outages trigger a fallback.
+type LocationProviderChain struct {
+	Providers []ChainedProvider
+	Logger    zerolog.Logger
+}
+
+func NewLocationProviderChain(logger zerolog.Logger, providers ...ChainedProvider) *LocationProviderChain {
+	return &LocationProviderChain{
+		Providers: providers,
+		Logger:    logger,
+	}
+}
+
+func (c *LocationProviderChain) Name() string {
+	return "chain"
+}
+
+func (c *LocationProviderChain) FindByZipCode(ctx context.Context, zipCode string) (*entities.Location, error) {
+	span := trace.SpanFromContext(ctx)
+
+	err := errors.New("no location provider configured")
+
+	for i, chained := range c.Providers {
+		var location *entities.Location
+		location, err = c.attempt(ctx, chained, zipCode)
+
+		var notFoundErr *customerrors.NotFoundError
+		if err == nil || errors.As(err, &notFoundErr) || ctx.Err() != nil {
+			span.SetAttributes(attribute.Int("location.provider.attempts", i+1))
+			return location, err
+		}
+
+		span.AddEvent("location.provider.failed", trace.WithAttributes(
+			locationProviderKey.String(chained.Provider.Name()),
+			attribute.String("error", err.Error()),
+		))
+		c.Logger.Warn().Msgf("[LocationProviderChain] Provider [%s] failed for zipcode [%s]: %s", chained.Provider.Name(), zipCode, err)
+	}
+
+	span.SetAttributes(attribute.Int("location.provider.attempts", len(c.Providers)))
+
+	return nil, err
+}
+
+func (c *LocationProviderChain) attempt(ctx context.Context, chained ChainedProvider, zipCode string) (*entities.Location, error) {
+	if chained.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, chained.Timeout)
+		defer cancel()
+	}
+
+	return chained.Provider.FindByZipCode(ctx, zipCode)
+}
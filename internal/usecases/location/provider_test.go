@@ -0,0 +1,131 @@
+package location
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+
+	"github.com/wellalencarweb/otel-lab-challenge/internal/entities"
+	"github.com/wellalencarweb/otel-lab-challenge/internal/pkg/customerrors"
+	"github.com/wellalencarweb/otel-lab-challenge/internal/pkg/mocks"
+)
+
+type LocationProviderChainTestSuite struct {
+	suite.Suite
+	Primary   *mocks.LocationProviderMock
+	Secondary *mocks.LocationProviderMock
+	Recorder  *tracetest.SpanRecorder
+	UseCase   *FindByZipCodeUseCase
+}
+
+func TestLocationProviderChain(t *testing.T) {
+	suite.Run(t, new(LocationProviderChainTestSuite))
+}
+
+func (s *LocationProviderChainTestSuite) SetupTest() {
+	s.Primary = new(mocks.LocationProviderMock)
+	s.Primary.On("Name").Return("viacep")
+	s.Secondary = new(mocks.LocationProviderMock)
+	s.Secondary.On("Name").Return("brasilapi")
+
+	chain := NewLocationProviderChain(zerolog.Nop(),
+		ChainedProvider{Provider: s.Primary, Timeout: 50 * time.Millisecond},
+		ChainedProvider{Provider: s.Secondary},
+	)
+
+	s.Recorder = tracetest.NewSpanRecorder()
+	s.UseCase = NewFindByZipCodeUseCase(chain, zerolog.Nop())
+}
+
+func (s *LocationProviderChainTestSuite) execute(zipCode string) (*entities.Location, map[string]interface{}, error) {
+	tracer := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(s.Recorder)).Tracer("location-test")
+	ctx, span := tracer.Start(context.Background(), "find-location-by-zipcode")
+
+	location, err := s.UseCase.Execute(ctx, zipCode)
+	span.End()
+
+	attrs := make(map[string]interface{})
+	for _, attr := range s.Recorder.Ended()[0].Attributes() {
+		attrs[string(attr.Key)] = attr.Value.AsInterface()
+	}
+
+	return location, attrs, err
+}
+
+func (s *LocationProviderChainTestSuite) TestFindByZipCode() {
+	s.Run("should answer from the first provider", func() {
+		s.SetupTest()
+		s.Primary.On("FindByZipCode", mock.Anything, "22021001").Return(&entities.Location{City: "Rio de Janeiro", Provider: "viacep"}, nil).Once()
+
+		location, attrs, err := s.execute("22021001")
+
+		s.Nil(err)
+		s.Equal("Rio de Janeiro", location.City)
+		s.Equal("viacep", attrs["location.provider"])
+		s.Equal(int64(1), attrs["location.provider.attempts"])
+		s.Secondary.AssertNotCalled(s.T(), "FindByZipCode", mock.Anything, mock.Anything)
+	})
+
+	s.Run("should fall back when a provider is unavailable", func() {
+		s.SetupTest()
+		s.Primary.On("FindByZipCode", mock.Anything, "22021001").Return((*entities.Location)(nil), &customerrors.ServiceUnavailableError{
+			Err:     errors.New("circuit open"),
+			Message: "viacep is unavailable",
+		}).Once()
+		s.Secondary.On("FindByZipCode", mock.Anything, "22021001").Return(&entities.Location{City: "Rio de Janeiro", Provider: "brasilapi"}, nil).Once()
+
+		location, attrs, err := s.execute("22021001")
+
+		s.Nil(err)
+		s.Equal("Rio de Janeiro", location.City)
+		s.Equal("brasilapi", attrs["location.provider"])
+		s.Equal(int64(2), attrs["location.provider.attempts"])
+		s.Equal("location.provider.failed", s.Recorder.Ended()[0].Events()[0].Name)
+	})
+
+	s.Run("should bound each provider by its timeout", func() {
+		s.SetupTest()
+		s.Primary.On("FindByZipCode", mock.Anything, "22021001").Return((*entities.Location)(nil), context.DeadlineExceeded).Run(func(args mock.Arguments) {
+			<-args.Get(0).(context.Context).Done()
+		}).Once()
+		s.Secondary.On("FindByZipCode", mock.Anything, "22021001").Return(&entities.Location{City: "Rio de Janeiro", Provider: "brasilapi"}, nil).Once()
+
+		start := time.Now()
+		location, _, err := s.execute("22021001")
+
+		s.Nil(err)
+		s.Equal("brasilapi", location.Provider)
+		s.Less(time.Since(start), time.Second)
+	})
+
+	s.Run("should not fall back when the zipcode is not found", func() {
+		s.SetupTest()
+		notFoundErr := &customerrors.NotFoundError{Message: "can not find zipcode"}
+		s.Primary.On("FindByZipCode", mock.Anything, "99999999").Return((*entities.Location)(nil), notFoundErr).Once()
+
+		location, _, err := s.execute("99999999")
+
+		s.Nil(location)
+		s.Equal(notFoundErr, err)
+		s.Secondary.AssertNotCalled(s.T(), "FindByZipCode", mock.Anything, mock.Anything)
+	})
+
+	s.Run("should return the last error when every provider fails", func() {
+		s.SetupTest()
+		s.Primary.On("FindByZipCode", mock.Anything, "22021001").Return((*entities.Location)(nil), &customerrors.ServiceUnavailableError{Message: "viacep is unavailable"}).Once()
+		s.Secondary.On("FindByZipCode", mock.Anything, "22021001").Return((*entities.Location)(nil), &customerrors.ServiceUnavailableError{Message: "brasilapi is unavailable"}).Once()
+
+		location, attrs, err := s.execute("22021001")
+
+		s.Nil(location)
+		s.EqualError(err, "brasilapi is unavailable")
+		s.Equal(int64(2), attrs["location.provider.attempts"])
+	})
+}
@@ -0,0 +1,63 @@
+package location
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/wellalencarweb/otel-lab-challenge/internal/pkg/customerrors"
+	"github.com/wellalencarweb/otel-lab-challenge/internal/pkg/mocks/cassettetest"
+)
+
+type ProvidersTestSuite struct {
+	suite.Suite
+}
+
+func TestProviders(t *testing.T) {
+	suite.Run(t, new(ProvidersTestSuite))
+}
+
+func (s *ProvidersTestSuite) TestBrasilApiProviderWithCassette() {
+	httpClient := cassettetest.NewHttpClient(s.T(), "brasilapi", "https://brasilapi.com.br/api", "brasilapi.json")
+	provider := NewBrasilApiProvider(httpClient)
+
+	s.Run("should decode location", func() {
+		result, err := provider.FindByZipCode(context.Background(), "22021-001")
+
+		s.Require().NoError(err)
+		s.Equal("Rio de Janeiro", result.City)
+		s.Equal("RJ", result.State)
+		s.Equal("Rua Barata Ribeiro", result.AddressLine1)
+		s.Equal("brasilapi", result.Provider)
+	})
+
+	s.Run("should return not found for unknown zipcode", func() {
+		result, err := provider.FindByZipCode(context.Background(), "99999999")
+
+		s.Nil(result)
+		s.IsType(&customerrors.NotFoundError{}, err)
+	})
+}
+
+func (s *ProvidersTestSuite) TestOpenCepProviderWithCassette() {
+	httpClient := cassettetest.NewHttpClient(s.T(), "opencep", "https://opencep.com", "opencep.json")
+	provider := NewOpenCepProvider(httpClient)
+
+	s.Run("should decode location", func() {
+		result, err := provider.FindByZipCode(context.Background(), "22021-001")
+
+		s.Require().NoError(err)
+		s.Equal("Rio de Janeiro", result.City)
+		s.Equal("RJ", result.State)
+		s.Equal("3304557", result.IBGECode)
+		s.Equal("opencep", result.Provider)
+	})
+
+	s.Run("should return not found for unknown zipcode", func() {
+		result, err := provider.FindByZipCode(context.Background(), "99999999")
+
+		s.Nil(result)
+		s.IsType(&customerrors.NotFoundError{}, err)
+	})
+}
@@ -0,0 +1,62 @@
+package location
+
+import (
+	"context"
+
+	"github.com/wellalencarweb/otel-lab-challenge/internal/entities"
+	"github.com/wellalencarweb/otel-lab-challenge/internal/pkg/httpclient"
+)
+
+type viaCepAddress struct {
+	Zipcode      string `json:"cep"`
+	AddressLine1 string `json:"logradouro"`
+	AddressLine2 string `json:"complemento"`
+	Neighborhood string `json:"bairro"`
+	City         string `json:"localidade"`
+	State        string `json:"uf"`
+	IBGECode     string `json:"ibge"`
+	GIACode      string `json:"gia"`
+	AreaCode     string `json:"ddd"`
+	SIAFICode    string `json:"siafi"`
+}
+
+// ViaCepProvider answers unknown zipcodes with a 200 and an empty address, which comes out as
+// a location without a city.
+type ViaCepProvider struct {
+	HttpClient httpclient.HttpClientInterface
+}
+
+func NewViaCepProvider(httpClient httpclient.HttpClientInterface) *ViaCepProvider {
+	return &ViaCepProvider{
+		HttpClient: httpClient,
+	}
+}
+
+func (p *ViaCepProvider) Name() string {
+	return "viacep"
+}
+
+func (p *ViaCepProvider) FindByZipCode(ctx context.Context, zipCode string) (*entities.Location, error) {
+	var address viaCepAddress
+
+	if err := p.HttpClient.Get(ctx, "/{zipcode}/json/", &address, httpclient.WithPathParam("zipcode", zipCode)); err != nil {
+		return nil, err.AsCustomError("can not find zipcode", "Unknown error getting location", map[string]interface{}{
+			"zipCode":  zipCode,
+			"provider": p.Name(),
+		})
+	}
+
+	return &entities.Location{
+		Zipcode:      address.Zipcode,
+		AddressLine1: address.AddressLine1,
+		AddressLine2: address.AddressLine2,
+		Neighborhood: address.Neighborhood,
+		City:         address.City,
+		State:        address.State,
+		IBGECode:     address.IBGECode,
+		GIACode:      address.GIACode,
+		AreaCode:     address.AreaCode,
+		SIAFICode:    address.SIAFICode,
+		Provider:     p.Name(),
+	}, nil
+}
@@ -0,0 +1,118 @@
+package location
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/wellalencarweb/otel-lab-challenge/internal/pkg/customerrors"
+	"github.com/wellalencarweb/otel-lab-challenge/internal/pkg/httpclient"
+	"github.com/wellalencarweb/otel-lab-challenge/internal/pkg/mocks"
+	"github.com/wellalencarweb/otel-lab-challenge/internal/pkg/mocks/cassettetest"
+)
+
+type ViaCepProviderTestSuite struct {
+	suite.Suite
+	HttpClientMock *mocks.HttpClientMock
+	ViaCepProvider *ViaCepProvider
+}
+
+func TestViaCepProvider(t *testing.T) {
+	suite.Run(t, new(ViaCepProviderTestSuite))
+}
+
+func (s *ViaCepProviderTestSuite) SetupTest() {
+	httpClientMock := new(mocks.HttpClientMock)
+
+	s.HttpClientMock = httpClientMock
+	s.ViaCepProvider = NewViaCepProvider(httpClientMock)
+}
+
+func (s *ViaCepProviderTestSuite) clearMocks() {
+	s.HttpClientMock.ExpectedCalls = nil
+}
+
+func (s *ViaCepProviderTestSuite) TestFindByZipCode() {
+	s.Run("should return location", func() {
+		defer s.clearMocks()
+
+		ctx := context.Background()
+		zipCode := "22021-001"
+		options := httpclient.NewRequestOptions(httpclient.WithPathParam("zipcode", zipCode))
+
+		s.HttpClientMock.On("Get", ctx, "/{zipcode}/json/", &viaCepAddress{}, options).Return(nil)
+
+		result, err := s.ViaCepProvider.FindByZipCode(ctx, zipCode)
+
+		s.Nil(err)
+		s.NotNil(result)
+		s.Equal("viacep", result.Provider)
+	})
+
+	s.Run("should return error when http client returns error", func() {
+		defer s.clearMocks()
+
+		ctx := context.Background()
+		zipCode := "22021-001"
+		options := httpclient.NewRequestOptions(httpclient.WithPathParam("zipcode", zipCode))
+
+		s.HttpClientMock.On("Get", ctx, "/{zipcode}/json/", &viaCepAddress{}, options).Return(&httpclient.HttpClientError{
+			Err: fmt.Errorf("any-error"),
+		})
+
+		result, err := s.ViaCepProvider.FindByZipCode(ctx, zipCode)
+
+		s.Error(err)
+		s.Nil(result)
+	})
+	s.Run("should map upstream errors to custom errors", func() {
+		ctx := context.Background()
+		zipCode := "22021-001"
+		options := httpclient.NewRequestOptions(httpclient.WithPathParam("zipcode", zipCode))
+
+		cases := []struct {
+			clientErr *httpclient.HttpClientError
+			expected  interface{}
+		}{
+			{&httpclient.HttpClientError{StatusCode: http.StatusNotFound, Err: fmt.Errorf("not found")}, &customerrors.NotFoundError{}},
+			{&httpclient.HttpClientError{StatusCode: http.StatusServiceUnavailable, Retryable: true, Err: fmt.Errorf("service unavailable")}, &customerrors.ServiceUnavailableError{}},
+			{&httpclient.HttpClientError{Retryable: true, Err: fmt.Errorf("connection refused")}, &customerrors.ServiceUnavailableError{}},
+			{&httpclient.HttpClientError{StatusCode: http.StatusUnauthorized, Err: fmt.Errorf("unauthorized")}, &customerrors.UnknownError{}},
+		}
+
+		for _, c := range cases {
+			s.HttpClientMock.On("Get", ctx, "/{zipcode}/json/", &viaCepAddress{}, options).Return(c.clientErr).Once()
+
+			result, err := s.ViaCepProvider.FindByZipCode(ctx, zipCode)
+
+			s.Nil(result)
+			s.IsType(c.expected, err)
+		}
+
+		s.clearMocks()
+	})
+}
+
+func (s *ViaCepProviderTestSuite) TestFindByZipCodeWithCassette() {
+	httpClient := cassettetest.NewHttpClient(s.T(), "viacep", "https://viacep.com.br/ws", "viacep.json")
+	provider := NewViaCepProvider(httpClient)
+
+	s.Run("should decode location", func() {
+		result, err := provider.FindByZipCode(context.Background(), "22021001")
+
+		s.Require().NoError(err)
+		s.Equal("Rio de Janeiro", result.City)
+		s.Equal("RJ", result.State)
+		s.Equal("3304557", result.IBGECode)
+	})
+
+	s.Run("should return empty location for unknown zipcode", func() {
+		result, err := provider.FindByZipCode(context.Background(), "99999999")
+
+		s.Require().NoError(err)
+		s.Empty(result.City)
+	})
+}